@@ -0,0 +1,223 @@
+// Package audit seals calculation results together with the inputs, the
+// resolved config and the engine version, so a disputed charge can be
+// recomputed and proven identical later.
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// EngineVersion identifies the calculation formulas. It must be bumped whenever
+// a change can alter any amount produced by calc for the same inputs.
+const EngineVersion = "1.4.0"
+
+var (
+	// ErrHashMismatch is returned when the envelope content does not match its hash.
+	ErrHashMismatch = errors.New("audit: hash mismatch")
+	// ErrEngineVersion is returned when recomputing an envelope sealed by another engine version.
+	ErrEngineVersion = errors.New("audit: engine version mismatch")
+	// ErrResultMismatch is returned when recomputing produces a different result.
+	ErrResultMismatch = errors.New("audit: recomputed result differs")
+)
+
+// Kind identifies the calculation sealed in an envelope.
+type Kind string
+
+const (
+	KindRotative    Kind = "rotative"
+	KindPayment     Kind = "payment"
+	KindInstallment Kind = "installment"
+)
+
+// Envelope is the audit record of a single calculation.
+// The caller (ledger) should persist it alongside the result.
+type Envelope struct {
+	Kind          Kind            `json:"kind"`
+	EngineVersion string          `json:"engine_version"`
+	Input         json.RawMessage `json:"input"`
+	Config        json.RawMessage `json:"config"`
+	Result        json.RawMessage `json:"result"`
+	Hash          string          `json:"hash"`
+}
+
+// RotativeInput holds the arguments of calc.CalculateRotative.
+// Override is the negotiated rate reference the service attached to the result.
+type RotativeInput struct {
+	Balance  domain.RotativeBalance
+	CalcDate time.Time
+	Override *domain.OverrideRef
+}
+
+// RotativeConfig is the resolved config snapshot used by calc.CalculateRotative,
+// after any per-account override was merged.
+type RotativeConfig struct {
+	IOF          config.IOFConfig
+	Interest     config.InterestConfig
+	LateFee      config.LateFeeConfig
+	LateInterest config.LateInterestConfig
+	Rules        config.RotativeRulesConfig
+}
+
+// PaymentInput holds the arguments of calc.ApplyPayment.
+type PaymentInput struct {
+	Total        domain.Money
+	IOF          domain.Money
+	Interest     domain.Money
+	LateInterest domain.Money
+	LateFee      domain.Money
+	Principal    domain.Money
+	Payment      domain.Money
+}
+
+// InstallmentInput holds the arguments of calc.CalculateInstallmentPlan.
+// Override is the negotiated rate reference the service attached to the plan.
+type InstallmentInput struct {
+	TotalAmount     domain.Money
+	NumInstallments int
+	PurchaseDate    time.Time
+	FirstDueDate    time.Time
+	Override        *domain.OverrideRef
+}
+
+// InstallmentConfig is the resolved config snapshot used by calc.CalculateInstallmentPlan.
+type InstallmentConfig struct {
+	IOF         config.IOFConfig
+	Installment config.InstallmentConfig
+}
+
+// SealRotative builds the envelope of a rotative calculation.
+func SealRotative(in RotativeInput, cfg RotativeConfig, result calc.RotativeResult) (Envelope, error) {
+	return seal(KindRotative, in, cfg, result)
+}
+
+// SealPayment builds the envelope of a payment application.
+// ApplyPayment takes no config, so the snapshot is empty.
+func SealPayment(in PaymentInput, result calc.AmortizationResult) (Envelope, error) {
+	return seal(KindPayment, in, struct{}{}, result)
+}
+
+// SealInstallment builds the envelope of an installment plan.
+func SealInstallment(in InstallmentInput, cfg InstallmentConfig, plan domain.InstallmentPlan) (Envelope, error) {
+	return seal(KindInstallment, in, cfg, plan)
+}
+
+// Verify checks that the envelope content matches its hash.
+func (e Envelope) Verify() error {
+	hash, err := e.computeHash()
+	if err != nil {
+		return err
+	}
+	if hash != e.Hash {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// Recompute verifies the envelope, runs the calculation again from the stored
+// input and config and checks the result is byte-for-byte identical.
+func Recompute(e Envelope) error {
+	if err := e.Verify(); err != nil {
+		return err
+	}
+	if e.EngineVersion != EngineVersion {
+		return fmt.Errorf("%w: sealed with %s, running %s", ErrEngineVersion, e.EngineVersion, EngineVersion)
+	}
+
+	var result any
+	switch e.Kind {
+	case KindRotative:
+		var in RotativeInput
+		var cfg RotativeConfig
+		if err := decode(e, &in, &cfg); err != nil {
+			return err
+		}
+		r := calc.CalculateRotative(in.Balance, in.CalcDate, cfg.IOF, cfg.Interest, cfg.LateFee, cfg.LateInterest, cfg.Rules)
+		r.Override = in.Override
+		result = r
+	case KindPayment:
+		var in PaymentInput
+		if err := decode(e, &in, nil); err != nil {
+			return err
+		}
+		result = calc.ApplyPayment(in.Total, in.IOF, in.Interest, in.LateInterest, in.LateFee, in.Principal, in.Payment)
+	case KindInstallment:
+		var in InstallmentInput
+		var cfg InstallmentConfig
+		if err := decode(e, &in, &cfg); err != nil {
+			return err
+		}
+		plan := calc.CalculateInstallmentPlan(in.TotalAmount, in.NumInstallments, in.PurchaseDate, in.FirstDueDate, cfg.IOF, cfg.Installment)
+		plan.Override = in.Override
+		result = plan
+	default:
+		return fmt.Errorf("audit: unknown kind %q", e.Kind)
+	}
+
+	got, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	// The stored result may have been re-indented by whoever persisted it.
+	var want bytes.Buffer
+	if err := json.Compact(&want, e.Result); err != nil {
+		return fmt.Errorf("audit: decode result: %w", err)
+	}
+	if !bytes.Equal(got, want.Bytes()) {
+		return ErrResultMismatch
+	}
+	return nil
+}
+
+func seal(kind Kind, input, cfg, result any) (Envelope, error) {
+	e := Envelope{Kind: kind, EngineVersion: EngineVersion}
+
+	var err error
+	if e.Input, err = json.Marshal(input); err != nil {
+		return Envelope{}, err
+	}
+	if e.Config, err = json.Marshal(cfg); err != nil {
+		return Envelope{}, err
+	}
+	if e.Result, err = json.Marshal(result); err != nil {
+		return Envelope{}, err
+	}
+	if e.Hash, err = e.computeHash(); err != nil {
+		return Envelope{}, err
+	}
+	return e, nil
+}
+
+// computeHash hashes every field but Hash. encoding/json emits struct fields
+// in declaration order, so the serialization is deterministic.
+func (e Envelope) computeHash() (string, error) {
+	e.Hash = ""
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func decode(e Envelope, in, cfg any) error {
+	if err := json.Unmarshal(e.Input, in); err != nil {
+		return fmt.Errorf("audit: decode input: %w", err)
+	}
+	if cfg == nil {
+		return nil
+	}
+	if err := json.Unmarshal(e.Config, cfg); err != nil {
+		return fmt.Errorf("audit: decode config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func utcDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func rotativeConfig() RotativeConfig {
+	return RotativeConfig{
+		IOF:          config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800},
+		Interest:     config.InterestConfig{MonthlyRate: 120_000},
+		LateFee:      config.LateFeeConfig{Rate: 20_000},
+		LateInterest: config.LateInterestConfig{MonthlyRate: 10_000},
+		Rules:        config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 1_000_000},
+	}
+}
+
+func sealedRotative(t *testing.T) Envelope {
+	t.Helper()
+	in := RotativeInput{
+		Balance:  domain.RotativeBalance{AccountID: "acc-1", Principal: 100_000, StartDate: utcDate(2024, 1, 1)},
+		CalcDate: utcDate(2024, 1, 31),
+	}
+	cfg := rotativeConfig()
+	result := calc.CalculateRotative(in.Balance, in.CalcDate, cfg.IOF, cfg.Interest, cfg.LateFee, cfg.LateInterest, cfg.Rules)
+
+	env, err := SealRotative(in, cfg, result)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	return env
+}
+
+func TestSealRotative_DeterministicHash(t *testing.T) {
+	a := sealedRotative(t)
+	b := sealedRotative(t)
+
+	if a.Hash == "" || a.Hash != b.Hash {
+		t.Fatalf("expected identical non-empty hashes, got %q and %q", a.Hash, b.Hash)
+	}
+	if a.EngineVersion != EngineVersion {
+		t.Fatalf("expected engine version %s, got %s", EngineVersion, a.EngineVersion)
+	}
+}
+
+func TestRecompute_RoundTripThroughJSON(t *testing.T) {
+	env := sealedRotative(t)
+
+	stored, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var loaded Envelope
+	if err := json.Unmarshal(stored, &loaded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if err := Recompute(loaded); err != nil {
+		t.Fatalf("expected recompute to match, got %v", err)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	env := sealedRotative(t)
+
+	var result calc.RotativeResult
+	if err := json.Unmarshal(env.Result, &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	result.Interest -= 100
+	env.Result, _ = json.Marshal(result)
+
+	if err := env.Verify(); !errors.Is(err, ErrHashMismatch) {
+		t.Fatalf("expected ErrHashMismatch, got %v", err)
+	}
+
+	// Re-hashing the tampered content passes Verify but not Recompute.
+	env.Hash, _ = env.computeHash()
+	if err := Recompute(env); !errors.Is(err, ErrResultMismatch) {
+		t.Fatalf("expected ErrResultMismatch, got %v", err)
+	}
+}
+
+func TestRecompute_RejectsOtherEngineVersion(t *testing.T) {
+	env := sealedRotative(t)
+	env.EngineVersion = "0.0.1"
+	env.Hash, _ = env.computeHash()
+
+	if err := Recompute(env); !errors.Is(err, ErrEngineVersion) {
+		t.Fatalf("expected ErrEngineVersion, got %v", err)
+	}
+}
+
+func TestRecompute_PaymentAndInstallment(t *testing.T) {
+	pay := PaymentInput{Total: 123_000, IOF: 6_000, Interest: 14_000, LateInterest: 1_000, LateFee: 2_000, Principal: 100_000, Payment: 40_000}
+	amort := calc.ApplyPayment(pay.Total, pay.IOF, pay.Interest, pay.LateInterest, pay.LateFee, pay.Principal, pay.Payment)
+	env, err := SealPayment(pay, amort)
+	if err != nil {
+		t.Fatalf("seal payment: %v", err)
+	}
+	if err := Recompute(env); err != nil {
+		t.Fatalf("payment recompute: %v", err)
+	}
+
+	in := InstallmentInput{TotalAmount: 100_000, NumInstallments: 12, PurchaseDate: utcDate(2024, 1, 5), FirstDueDate: utcDate(2024, 2, 10)}
+	cfg := InstallmentConfig{
+		IOF:         config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800},
+		Installment: config.InstallmentConfig{MonthlyRate: 19_900},
+	}
+	plan := calc.CalculateInstallmentPlan(in.TotalAmount, in.NumInstallments, in.PurchaseDate, in.FirstDueDate, cfg.IOF, cfg.Installment)
+	env, err = SealInstallment(in, cfg, plan)
+	if err != nil {
+		t.Fatalf("seal installment: %v", err)
+	}
+	if err := Recompute(env); err != nil {
+		t.Fatalf("installment recompute: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package calc
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// DailyAccrual is the increment of rotative charges recognized on Date, for
+// the day ending on Date.
+type DailyAccrual struct {
+	Date         time.Time
+	Interest     domain.Money
+	IOF          domain.Money
+	LateFee      domain.Money
+	LateInterest domain.Money
+	Charges      domain.Money
+}
+
+// AccrueRotative returns one DailyAccrual per day in (from, to] for balance.
+//
+// Each day posts the difference between CalculateRotative at that date and at
+// the day before, so rounding residues fall on the day the cumulative rounded
+// amount moves and the increments of every bucket sum exactly to
+// CalculateRotative(to) - CalculateRotative(from). With from = balance.StartDate
+// nothing was recognized before, so they sum to the closed-form result at to
+// and the first day also carries the additional IOF. The late fee posts on
+// the first overdue day; nothing accrues after RotativeRulesConfig.MaxDays.
+// Once the charge cap applies, interest (and below IOF + multa + mora, late
+// interest and the late fee) is reduced as the other charges grow, so those
+// increments can be negative while Charges never is.
+//
+// Input validation (StartDate <= from <= to) is the caller's responsibility.
+func AccrueRotative(
+	balance domain.RotativeBalance,
+	from time.Time,
+	to time.Time,
+	iofCfg config.IOFConfig,
+	intCfg config.InterestConfig,
+	lateFeeCfg config.LateFeeConfig,
+	lateInterestCfg config.LateInterestConfig,
+	rulesCfg config.RotativeRulesConfig,
+) []DailyAccrual {
+	days := daysBetween(from, to)
+	accruals := make([]DailyAccrual, 0, days)
+
+	// Nothing is recognized before the start date, so the first day also
+	// posts the upfront additional IOF.
+	var prev RotativeResult
+	if from.After(balance.StartDate) {
+		prev = CalculateRotative(balance, from, iofCfg, intCfg, lateFeeCfg, lateInterestCfg, rulesCfg)
+	}
+	for i := 1; i <= days; i++ {
+		date := from.AddDate(0, 0, i)
+		cur := CalculateRotative(balance, date, iofCfg, intCfg, lateFeeCfg, lateInterestCfg, rulesCfg)
+		accruals = append(accruals, DailyAccrual{
+			Date:         date,
+			Interest:     cur.Interest - prev.Interest,
+			IOF:          cur.IOF - prev.IOF,
+			LateFee:      cur.LateFee - prev.LateFee,
+			LateInterest: cur.LateInterest - prev.LateInterest,
+			Charges:      cur.Charges - prev.Charges,
+		})
+		prev = cur
+	}
+	return accruals
+}
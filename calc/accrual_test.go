@@ -0,0 +1,96 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func sumAccruals(accruals []DailyAccrual) DailyAccrual {
+	var sum DailyAccrual
+	for _, a := range accruals {
+		sum.Interest += a.Interest
+		sum.IOF += a.IOF
+		sum.LateFee += a.LateFee
+		sum.LateInterest += a.LateInterest
+		sum.Charges += a.Charges
+	}
+	return sum
+}
+
+func TestAccrueRotative_SumsToClosedForm(t *testing.T) {
+	start := utcDate(2024, 1, 10)
+	// Odd principals make the daily amounts fractional.
+	for _, principal := range []domain.Money{1, 333, 100_001, 987_654} {
+		for _, end := range []int{1, 7, 29, 30, 45} {
+			balance := domain.RotativeBalance{Principal: principal, StartDate: start}
+			to := start.AddDate(0, 0, end)
+
+			accruals := AccrueRotative(balance, start, to, defaultIOFConfig(), defaultInterestConfig(),
+				defaultLateFeeConfig(), defaultLateInterestConfig(), defaultRotativeRulesConfig())
+			closed := CalculateRotative(balance, to, defaultIOFConfig(), defaultInterestConfig(),
+				defaultLateFeeConfig(), defaultLateInterestConfig(), defaultRotativeRulesConfig())
+
+			if len(accruals) != end {
+				t.Fatalf("principal %d, %d days: expected %d entries, got %d", principal, end, end, len(accruals))
+			}
+			sum := sumAccruals(accruals)
+			if sum.Interest != closed.Interest || sum.IOF != closed.IOF || sum.LateFee != closed.LateFee ||
+				sum.LateInterest != closed.LateInterest || sum.Charges != closed.Charges {
+				t.Fatalf("principal %d, %d days: sum %+v differs from closed form %+v", principal, end, sum, closed)
+			}
+		}
+	}
+}
+
+func TestAccrueRotative_DailyIncrements(t *testing.T) {
+	start := utcDate(2024, 1, 10)
+	balance := domain.RotativeBalance{Principal: 100_000, StartDate: start}
+	accruals := AccrueRotative(balance, start, start.AddDate(0, 0, 40), defaultIOFConfig(), defaultInterestConfig(),
+		defaultLateFeeConfig(), defaultLateInterestConfig(), defaultRotativeRulesConfig())
+
+	// 12% a.m. over 1000.00 = 4.00 per day.
+	if accruals[0].Interest != 400 || accruals[0].LateFee != 2_000 {
+		t.Fatalf("day 1: expected interest 400 and late fee 2000, got %+v", accruals[0])
+	}
+	for i, a := range accruals[1:30] {
+		if a.LateFee != 0 || a.Interest != 400 {
+			t.Fatalf("day %d: expected only 400 interest and no late fee, got %+v", i+2, a)
+		}
+	}
+	// MaxDays = 30: nothing accrues afterwards.
+	for i, a := range accruals[30:] {
+		if a.Charges != 0 {
+			t.Fatalf("day %d: expected no accrual after MaxDays, got %+v", i+31, a)
+		}
+	}
+	if !accruals[0].Date.Equal(utcDate(2024, 1, 11)) {
+		t.Fatalf("expected first entry on 2024-01-11, got %v", accruals[0].Date)
+	}
+}
+
+func TestAccrueRotative_PartialRangeTelescopes(t *testing.T) {
+	start := utcDate(2024, 1, 10)
+	balance := domain.RotativeBalance{Principal: 123_457, StartDate: start}
+	from, to := start.AddDate(0, 0, 5), start.AddDate(0, 0, 20)
+	// A cap low enough to bind inside the range.
+	rules := config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 80_000}
+
+	accruals := AccrueRotative(balance, from, to, defaultIOFConfig(), defaultInterestConfig(),
+		defaultLateFeeConfig(), defaultLateInterestConfig(), rules)
+	atFrom := CalculateRotative(balance, from, defaultIOFConfig(), defaultInterestConfig(),
+		defaultLateFeeConfig(), defaultLateInterestConfig(), rules)
+	atTo := CalculateRotative(balance, to, defaultIOFConfig(), defaultInterestConfig(),
+		defaultLateFeeConfig(), defaultLateInterestConfig(), rules)
+
+	sum := sumAccruals(accruals)
+	if sum.Charges != atTo.Charges-atFrom.Charges || sum.Interest != atTo.Interest-atFrom.Interest {
+		t.Fatalf("expected range sum %d, got %d", atTo.Charges-atFrom.Charges, sum.Charges)
+	}
+	for _, a := range accruals {
+		if a.Charges < 0 {
+			t.Fatalf("%v: negative charges %d", a.Date, a.Charges)
+		}
+	}
+}
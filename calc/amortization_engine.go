@@ -3,7 +3,8 @@ package calc
 import "github.com/thiagozs/go-calc-charges-engine/domain"
 
 // AmortizationResult detalha como o pagamento foi aplicado seguindo a regra bancaria.
-// The caller (ledger) should persist this struct for audit trail purposes.
+// The caller (ledger) should persist this struct for audit trail purposes,
+// sealed with audit.SealPayment so inputs are kept with it.
 type AmortizationResult struct {
 	PaidIOF          domain.Money
 	PaidInterest     domain.Money
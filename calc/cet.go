@@ -0,0 +1,88 @@
+package calc
+
+import (
+	"math/big"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// cetMaxMonthlyRate bounds the CET search (100% a.m.).
+const cetMaxMonthlyRate = domain.Rate(1_000_000)
+
+// cetScale is the fixed-point scale (18 decimals) used by the CET search.
+var cetScale = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// CET is the custo efetivo total of an installment plan.
+type CET struct {
+	Monthly domain.Rate
+	Annual  domain.Rate
+}
+
+// CalculateCET computes the custo efetivo total of a plan: the monthly rate
+// that discounts every installment Amount (principal + interest + IOF) back to
+// the financed amount, and its annual equivalent (1+m)^12 - 1.
+//
+// Installments are discounted by whole monthly periods (installment i at
+// period i), as shown at checkout. The monthly rate is rounded up to the next
+// millionth, so the disclosed CET never understates the cost.
+func CalculateCET(financed domain.Money, plan domain.InstallmentPlan) CET {
+	if financed <= 0 || len(plan.Installments) == 0 {
+		return CET{}
+	}
+
+	target := new(big.Int).Mul(big.NewInt(int64(financed)), cetScale)
+	if presentValue(plan, 0).Cmp(target) <= 0 {
+		return CET{}
+	}
+
+	// presentValue decreases as the rate grows: find the smallest rate whose
+	// present value does not exceed the financed amount.
+	lo, hi := domain.Rate(0), cetMaxMonthlyRate
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		if presentValue(plan, mid).Cmp(target) > 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return CET{Monthly: hi, Annual: annualize(hi)}
+}
+
+// presentValue returns the installments discounted at the monthly rate,
+// in centavos scaled by cetScale.
+func presentValue(plan domain.InstallmentPlan, rate domain.Rate) *big.Int {
+	sum := new(big.Int)
+	factor := new(big.Int).Set(cetScale)
+	base := scaledOnePlus(rate)
+	for _, inst := range plan.Installments {
+		factor.Mul(factor, base).Quo(factor, cetScale)
+		pv := new(big.Int).Mul(big.NewInt(int64(inst.Amount)), cetScale)
+		pv.Mul(pv, cetScale).Quo(pv, factor)
+		sum.Add(sum, pv)
+	}
+	return sum
+}
+
+// annualize converts a monthly rate to (1+m)^12 - 1, rounded half-up.
+func annualize(monthly domain.Rate) domain.Rate {
+	pow := new(big.Int).Set(cetScale)
+	base := scaledOnePlus(monthly)
+	for range 12 {
+		pow.Mul(pow, base).Quo(pow, cetScale)
+	}
+	pow.Sub(pow, cetScale)
+
+	// Rescale from 18 to 6 decimals.
+	div := new(big.Int).Quo(cetScale, big.NewInt(domain.RateDenominator))
+	pow.Add(pow, new(big.Int).Quo(div, big.NewInt(2)))
+	return domain.Rate(pow.Quo(pow, div).Int64())
+}
+
+// scaledOnePlus returns (1 + rate) scaled by cetScale.
+func scaledOnePlus(rate domain.Rate) *big.Int {
+	r := new(big.Int).Mul(big.NewInt(int64(rate)), cetScale)
+	r.Quo(r, big.NewInt(domain.RateDenominator))
+	return r.Add(r, cetScale)
+}
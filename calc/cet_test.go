@@ -0,0 +1,54 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestCalculateCET_NoChargesIsZero(t *testing.T) {
+	plan := CalculateInstallmentPlan(100_000, 10, utcDate(2024, 1, 5), utcDate(2024, 2, 10),
+		config.IOFConfig{}, config.InstallmentConfig{MonthlyRate: 0})
+
+	cet := CalculateCET(100_000, plan)
+	if cet.Monthly != 0 || cet.Annual != 0 {
+		t.Fatalf("expected zero CET, got %+v", cet)
+	}
+}
+
+func TestCalculateCET_WithoutIOFEqualsContractRate(t *testing.T) {
+	// Without IOF the CET is the Tabela Price rate itself (up to the
+	// centavo rounding of the installments).
+	plan := CalculateInstallmentPlan(100_000, 12, utcDate(2024, 1, 5), utcDate(2024, 2, 10),
+		config.IOFConfig{}, config.InstallmentConfig{MonthlyRate: 19_900})
+
+	cet := CalculateCET(100_000, plan)
+	if cet.Monthly < 19_890 || cet.Monthly > 19_910 {
+		t.Fatalf("expected monthly CET ~19900, got %d", cet.Monthly)
+	}
+	// (1.0199)^12 - 1 = 26.64%
+	if cet.Annual < 266_000 || cet.Annual > 266_800 {
+		t.Fatalf("expected annual CET ~266400, got %d", cet.Annual)
+	}
+}
+
+func TestCalculateCET_IOFRaisesCost(t *testing.T) {
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900}
+	withIOF := CalculateInstallmentPlan(100_000, 12, utcDate(2024, 1, 5), utcDate(2024, 2, 10), defaultIOFConfig(), instCfg)
+	withoutIOF := CalculateInstallmentPlan(100_000, 12, utcDate(2024, 1, 5), utcDate(2024, 2, 10), config.IOFConfig{}, instCfg)
+
+	if CalculateCET(100_000, withIOF).Monthly <= CalculateCET(100_000, withoutIOF).Monthly {
+		t.Fatalf("expected IOF to raise the CET")
+	}
+}
+
+func TestAnnualize(t *testing.T) {
+	if got := annualize(0); got != 0 {
+		t.Fatalf("expected 0, got %d", got)
+	}
+	// (1.01)^12 - 1 = 0.126825...
+	if got := annualize(10_000); got != domain.Rate(126_825) {
+		t.Fatalf("expected 126825, got %d", got)
+	}
+}
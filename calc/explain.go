@@ -0,0 +1,551 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// Explanation is a step-by-step breakdown of a calculation (memoria de calculo),
+// meant for support agents and consumer complaints. It marshals to JSON as is
+// and renders as Portuguese text with Text.
+type Explanation struct {
+	Title  string       `json:"title"`
+	Notes  []string     `json:"notes,omitempty"`
+	Steps  []Step       `json:"steps"`
+	Result domain.Money `json:"result"`
+}
+
+// Step is a single operation of an explanation.
+type Step struct {
+	Name        string       `json:"name"`
+	Formula     string       `json:"formula"`
+	Operands    []Operand    `json:"operands,omitempty"`
+	Description string       `json:"description"`
+	Exact       string       `json:"exact,omitempty"`
+	Rounding    string       `json:"rounding,omitempty"`
+	Capped      bool         `json:"capped,omitempty"`
+	Result      domain.Money `json:"result"`
+}
+
+// Operand kinds used in Operand.Kind.
+const (
+	OperandMoney = "money"
+	OperandRate  = "rate"
+	OperandDays  = "days"
+	OperandCount = "count"
+)
+
+// Operand is an input of a step. Value is in centavos for money, in
+// millionths for rates (domain.Rate) and in units for days and counts.
+type Operand struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Value int64  `json:"value"`
+}
+
+// roundingName names the rounding applied with mode.
+func roundingName(mode domain.RoundingMode) string {
+	if mode == "" {
+		return string(domain.RoundHalfUp)
+	}
+	return string(mode)
+}
+
+// Text renders the explanation in Portuguese, one line per step.
+func (e Explanation) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Memoria de calculo: %s\n", e.Title)
+	for _, n := range e.Notes {
+		fmt.Fprintf(&b, "- %s\n", n)
+	}
+	for i, s := range e.Steps {
+		fmt.Fprintf(&b, "%d. %s: %s = %s\n", i+1, s.Name, s.Description, formatBRL(s.Result))
+		var details []string
+		if s.Exact != "" {
+			details = append(details, "valor exato "+s.Exact)
+		}
+		if s.Rounding != "" {
+			details = append(details, "arredondamento "+roundingLabel(s.Rounding))
+		}
+		if s.Capped {
+			details = append(details, "teto aplicado")
+		}
+		if len(details) > 0 {
+			fmt.Fprintf(&b, "   (%s)\n", strings.Join(details, "; "))
+		}
+	}
+	fmt.Fprintf(&b, "Resultado: %s\n", formatBRL(e.Result))
+	return b.String()
+}
+
+// ExplainIOF explains CalculateIOF for the same arguments.
+func ExplainIOF(principal domain.Money, days int, cfg config.IOFConfig) Explanation {
+	steps, iof := iofSteps(principal, days, cfg)
+	return Explanation{
+		Title:  "IOF",
+		Steps:  steps,
+		Result: iof,
+	}
+}
+
+// ExplainRotative explains CalculateRotative for the same arguments.
+// Amounts come from the calc functions themselves, so the explanation always
+// matches the result.
+func ExplainRotative(
+	balance domain.RotativeBalance,
+	calcDate time.Time,
+	iofCfg config.IOFConfig,
+	intCfg config.InterestConfig,
+	lateFeeCfg config.LateFeeConfig,
+	lateInterestCfg config.LateInterestConfig,
+	rulesCfg config.RotativeRulesConfig,
+) Explanation {
+	result := CalculateRotative(balance, calcDate, iofCfg, intCfg, lateFeeCfg, lateInterestCfg, rulesCfg)
+	p := balance.Principal
+	d := result.ChargedDays
+
+	notes := []string{
+		fmt.Sprintf("Periodo: %s a %s = %d dias", formatDate(balance.StartDate), formatDate(calcDate), result.Days),
+	}
+	if result.ChargedDays != result.Days {
+		notes = append(notes, fmt.Sprintf("Dias cobrados limitados a %d (regra do rotativo)", result.ChargedDays))
+	}
+
+	var steps []Step
+	uncappedInterest := CalculateRotativeInterest(p, d, intCfg)
+	steps = append(steps, Step{
+		Name:        "Juros rotativo",
+		Formula:     "saldo × taxa mensal / 30 × dias",
+		Operands:    []Operand{moneyOperand("saldo", p), rateOperand("taxa mensal", intCfg.MonthlyRate), daysOperand("dias", d)},
+		Description: fmt.Sprintf("Saldo %s × %s/30 × %d dias", formatBRL(p), formatPercent(intCfg.MonthlyRate), d),
+		Exact:       formatExact(30*domain.RateDenominator, int64(p), int64(intCfg.MonthlyRate), int64(d)),
+		Rounding:    roundingName(intCfg.Rounding),
+		Result:      uncappedInterest,
+	})
+
+	iofSt, _ := iofSteps(p, d, iofCfg)
+	steps = append(steps, iofSt...)
+
+	if result.Days > 0 {
+		steps = append(steps, Step{
+			Name:        "Multa",
+			Formula:     "saldo × taxa de multa",
+			Operands:    []Operand{moneyOperand("saldo", p), rateOperand("taxa de multa", lateFeeCfg.Rate)},
+			Description: fmt.Sprintf("Saldo %s × %s", formatBRL(p), formatPercent(lateFeeCfg.Rate)),
+			Exact:       formatExact(domain.RateDenominator, int64(p), int64(lateFeeCfg.Rate)),
+			Rounding:    roundingName(lateFeeCfg.Rounding),
+			Result:      CalculateLateFee(p, lateFeeCfg),
+		})
+		steps = append(steps, Step{
+			Name:        "Juros de mora",
+			Formula:     "saldo × taxa mensal de mora / 30 × dias",
+			Operands:    []Operand{moneyOperand("saldo", p), rateOperand("taxa mensal de mora", lateInterestCfg.MonthlyRate), daysOperand("dias", d)},
+			Description: fmt.Sprintf("Saldo %s × %s/30 × %d dias", formatBRL(p), formatPercent(lateInterestCfg.MonthlyRate), d),
+			Exact:       formatExact(30*domain.RateDenominator, int64(p), int64(lateInterestCfg.MonthlyRate), int64(d)),
+			Rounding:    roundingName(lateInterestCfg.Rounding),
+			Result:      CalculateLateInterest(p, d, lateInterestCfg),
+		})
+	} else {
+		notes = append(notes, "Sem atraso: multa e juros de mora nao se aplicam")
+	}
+
+	if rulesCfg.MaxChargeRate > 0 {
+		maxCharges := mulRate(p, rulesCfg.MaxChargeRate, rulesCfg.Rounding)
+		steps = append(steps, Step{
+			Name:        "Teto de encargos",
+			Formula:     "saldo × teto de encargos",
+			Operands:    []Operand{moneyOperand("saldo", p), rateOperand("teto de encargos", rulesCfg.MaxChargeRate)},
+			Description: fmt.Sprintf("Saldo %s × %s", formatBRL(p), formatPercent(rulesCfg.MaxChargeRate)),
+			Exact:       formatExact(domain.RateDenominator, int64(p), int64(rulesCfg.MaxChargeRate)),
+			Rounding:    roundingName(rulesCfg.Rounding),
+			Result:      maxCharges,
+		})
+		if result.ChargeCapped {
+			others := result.IOF + result.LateFee + result.LateInterest
+			steps = append(steps, Step{
+				Name:    "Juros rotativo apos teto",
+				Formula: "teto de encargos − (IOF + multa + juros de mora)",
+				Operands: []Operand{
+					moneyOperand("teto de encargos", maxCharges),
+					moneyOperand("demais encargos", others),
+				},
+				Description: fmt.Sprintf("%s − %s", formatBRL(maxCharges), formatBRL(others)),
+				Capped:      true,
+				Result:      result.Interest,
+			})
+
+			// Below IOF + multa + mora, the cap also cuts mora, then multa.
+			lateInterest, lateFee := CalculateLateInterest(p, d, lateInterestCfg), CalculateLateFee(p, lateFeeCfg)
+			if result.Days > 0 && (result.LateInterest < lateInterest || result.LateFee < lateFee) {
+				notes = append(notes, "Teto de encargos menor que IOF + multa + juros de mora: juros de mora e multa reduzidos (IOF nao e reduzido)")
+			}
+			if result.Days > 0 && result.LateInterest < lateInterest {
+				steps = append(steps, Step{
+					Name:        "Juros de mora apos teto",
+					Formula:     "juros de mora − excedente do teto",
+					Description: fmt.Sprintf("%s reduzido para %s", formatBRL(lateInterest), formatBRL(result.LateInterest)),
+					Capped:      true,
+					Result:      result.LateInterest,
+				})
+			}
+			if result.Days > 0 && result.LateFee < lateFee {
+				steps = append(steps, Step{
+					Name:        "Multa apos teto",
+					Formula:     "multa − excedente do teto",
+					Description: fmt.Sprintf("%s reduzida para %s", formatBRL(lateFee), formatBRL(result.LateFee)),
+					Capped:      true,
+					Result:      result.LateFee,
+				})
+			}
+		}
+	}
+
+	steps = append(steps,
+		Step{
+			Name:    "Total de encargos",
+			Formula: "juros + IOF + multa + juros de mora",
+			Description: fmt.Sprintf("%s + %s + %s + %s",
+				formatBRL(result.Interest), formatBRL(result.IOF), formatBRL(result.LateFee), formatBRL(result.LateInterest)),
+			Capped: result.ChargeCapped,
+			Result: result.Charges,
+		},
+		Step{
+			Name:        "Total devido",
+			Formula:     "saldo + encargos",
+			Description: fmt.Sprintf("%s + %s", formatBRL(p), formatBRL(result.Charges)),
+			Result:      result.Total,
+		},
+	)
+
+	return Explanation{
+		Title:  "Rotativo",
+		Notes:  notes,
+		Steps:  steps,
+		Result: result.Total,
+	}
+}
+
+// ExplainInstallmentPlan explains CalculateInstallmentPlan for the same arguments.
+func ExplainInstallmentPlan(
+	totalAmount domain.Money,
+	numInstallments int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+) Explanation {
+	plan := CalculateInstallmentPlan(totalAmount, numInstallments, purchaseDate, firstDueDate, iofCfg, instCfg)
+	n := len(plan.Installments)
+	r := instCfg.MonthlyRate
+	merchant := plan.Funding == domain.FundingMerchant
+	if merchant {
+		r = 0
+	}
+
+	notes := []string{fmt.Sprintf("Compra de %s em %s, %d parcelas a partir de %s",
+		formatBRL(totalAmount), formatDate(purchaseDate), n, formatDate(firstDueDate))}
+	if merchant {
+		notes = append(notes, "Parcelado lojista: sem juros e sem IOF para o portador")
+	}
+
+	// With the IOF financed, the plan amortizes the purchase plus its IOF.
+	principal := totalAmount
+	if plan.IOFFinanced {
+		principal += plan.TotalIOF
+		notes = append(notes, fmt.Sprintf("IOF financiado: %s incorporado ao valor financiado (%s), parcelas sem IOF por fora",
+			formatBRL(plan.TotalIOF), formatBRL(principal)))
+	}
+
+	var steps []Step
+	if r == 0 {
+		base := principal / domain.Money(n)
+		steps = append(steps, Step{
+			Name:        "Parcela sem juros",
+			Formula:     "valor financiado / parcelas",
+			Operands:    []Operand{moneyOperand("valor financiado", principal), countOperand("parcelas", n)},
+			Description: fmt.Sprintf("%s / %d", formatBRL(principal), n),
+			Exact:       formatExact(int64(n), int64(principal)),
+			Rounding:    "down",
+			Result:      base,
+		})
+		if rem := principal - base*domain.Money(n); rem > 0 {
+			target := "1a parcela"
+			if instCfg.Remainder == domain.RemainderLast {
+				target = "ultima parcela"
+			}
+			notes = append(notes, fmt.Sprintf("Resto de %s somado a %s", formatBRL(rem), target))
+		}
+	} else {
+		financed := principal + plan.GraceInterest
+		if plan.GraceInterest > 0 {
+			firstDays := daysBetween(purchaseDate, firstDueDate)
+			steps = append(steps, Step{
+				Name:        "Juros de carencia",
+				Formula:     "PV × (1+i)^(meses) × (1 + i × dias/30) − PV",
+				Operands:    []Operand{moneyOperand("PV", principal), rateOperand("i", r), daysOperand("dias alem do 1o periodo", firstDays-30)},
+				Description: fmt.Sprintf("%s capitalizado por %d dias alem de 30", formatBRL(principal), firstDays-30),
+				Rounding:    roundingName(instCfg.Rounding),
+				Result:      plan.GraceInterest,
+			})
+			notes = append(notes, fmt.Sprintf("Carencia: juros de %s incorporados ao valor financiado", formatBRL(plan.GraceInterest)))
+		}
+		if plan.GraceInterest < 0 {
+			firstDays := daysBetween(purchaseDate, firstDueDate)
+			steps = append(steps, Step{
+				Name:        "Desconto do 1o periodo",
+				Formula:     "PV × (1 + i × dias/30) / (1+i) − PV",
+				Operands:    []Operand{moneyOperand("PV", principal), rateOperand("i", r), daysOperand("dias do 1o periodo", firstDays)},
+				Description: fmt.Sprintf("%s descontado por %d dias abaixo de 30", formatBRL(principal), 30-firstDays),
+				Rounding:    roundingName(instCfg.Rounding),
+				Result:      plan.GraceInterest,
+			})
+			notes = append(notes, fmt.Sprintf("1o periodo de %d dias: desconto de %s nos juros da 1a parcela", firstDays, formatBRL(-plan.GraceInterest)))
+		}
+
+		first := plan.Installments[0]
+		pmt := first.Principal + first.Interest
+		if plan.IOFFinanced {
+			pmt += first.IOF
+		}
+		// (1+i)^n is shown from the exact power the PMT uses, not a rounded
+		// fixed-point rate.
+		bn, dn := ratePowers(r, n)
+		power := formatDecimal(bn, dn, 12)
+		pmtNum, pmtDen := pricePMTExact(financed, r, n)
+		steps = append(steps, Step{
+			Name:        "Parcela (Tabela Price)",
+			Formula:     "PV × i × (1+i)^n / ((1+i)^n − 1)",
+			Operands:    []Operand{moneyOperand("PV", financed), rateOperand("i", r), countOperand("n", n)},
+			Description: fmt.Sprintf("(1+%s)^%d = %s; %s × %s × %s / (%s − 1)", formatPercent(r), n, power, formatBRL(financed), formatPercent(r), power, power),
+			Exact:       formatExactBig(pmtNum, pmtDen),
+			Rounding:    roundingName(instCfg.Rounding),
+			Result:      pmt,
+		})
+	}
+
+	balance := principal + plan.GraceInterest
+	for _, inst := range plan.Installments {
+		label := fmt.Sprintf("Parcela %d (%s)", inst.Number, formatDate(inst.DueDate))
+		days := daysBetween(purchaseDate, inst.DueDate)
+		amortization := inst.Principal
+		if plan.IOFFinanced {
+			amortization += inst.IOF
+		}
+
+		if r != 0 {
+			steps = append(steps, Step{
+				Name:        label + " - juros",
+				Formula:     "saldo devedor × taxa mensal",
+				Operands:    []Operand{moneyOperand("saldo devedor", balance), rateOperand("taxa mensal", r)},
+				Description: fmt.Sprintf("Saldo %s × %s", formatBRL(balance), formatPercent(r)),
+				Exact:       formatExact(domain.RateDenominator, int64(balance), int64(r)),
+				Rounding:    roundingName(instCfg.Rounding),
+				Result:      inst.Interest,
+			})
+		}
+
+		if !merchant {
+			steps = append(steps, Step{
+				Name:     label + " - IOF",
+				Formula:  "min(amortizacao × (taxa diaria × dias + adicional), amortizacao × teto)",
+				Operands: []Operand{moneyOperand("amortizacao", amortization), rateOperand("taxa diaria", iofCfg.DailyRate), daysOperand("dias", days), rateOperand("adicional", iofCfg.AdditionalRate)},
+				Description: fmt.Sprintf("%s × (%s × %d dias + %s)",
+					formatBRL(amortization), formatPercent(iofCfg.DailyRate), days, formatPercent(iofCfg.AdditionalRate)),
+				Rounding: roundingName(iofCfg.Rounding),
+				Capped:   iofCapped(amortization, days, iofCfg),
+				Result:   inst.IOF,
+			})
+		}
+
+		desc := fmt.Sprintf("%s + %s", formatBRL(inst.Principal), formatBRL(inst.IOF))
+		formula := "amortizacao + IOF"
+		if r != 0 {
+			desc = fmt.Sprintf("%s + %s + %s", formatBRL(inst.Principal), formatBRL(inst.Interest), formatBRL(inst.IOF))
+			formula = "amortizacao + juros + IOF"
+		}
+		steps = append(steps, Step{
+			Name:        label + " - total",
+			Formula:     formula,
+			Description: desc,
+			Result:      inst.Amount,
+		})
+
+		// Interest beyond the balance interest is amortized grace interest;
+		// interest below it is the discount of a short first period.
+		balance -= amortization
+		if r != 0 && inst.Number < len(plan.Installments) {
+			balance -= inst.Interest - mulRate(balance+amortization, r, instCfg.Rounding)
+		}
+	}
+
+	steps = append(steps, Step{
+		Name:        "Total do parcelamento",
+		Formula:     "valor da compra + juros + IOF",
+		Description: fmt.Sprintf("%s + %s + %s", formatBRL(plan.TotalAmount), formatBRL(plan.TotalInterest), formatBRL(plan.TotalIOF)),
+		Result:      plan.TotalWithIOF,
+	})
+
+	return Explanation{
+		Title:  "Parcelamento",
+		Notes:  notes,
+		Steps:  steps,
+		Result: plan.TotalWithIOF,
+	}
+}
+
+// iofSteps mirrors CalculateIOF and returns its steps and final value.
+func iofSteps(principal domain.Money, days int, cfg config.IOFConfig) ([]Step, domain.Money) {
+	daily := mulRateDays(principal, cfg.DailyRate, days, cfg.Rounding)
+	additional := mulRate(principal, cfg.AdditionalRate, cfg.Rounding)
+	maxVal := mulRate(principal, cfg.MaxAnnualRate, cfg.Rounding)
+	iof := CalculateIOF(principal, days, cfg)
+
+	steps := []Step{
+		{
+			Name:        "IOF diario",
+			Formula:     "saldo × taxa diaria × dias",
+			Operands:    []Operand{moneyOperand("saldo", principal), rateOperand("taxa diaria", cfg.DailyRate), daysOperand("dias", days)},
+			Description: fmt.Sprintf("Saldo %s × %s × %d dias", formatBRL(principal), formatPercent(cfg.DailyRate), days),
+			Exact:       formatExact(domain.RateDenominator, int64(principal), int64(cfg.DailyRate), int64(days)),
+			Rounding:    roundingName(cfg.Rounding),
+			Result:      daily,
+		},
+		{
+			Name:        "IOF adicional",
+			Formula:     "saldo × aliquota adicional",
+			Operands:    []Operand{moneyOperand("saldo", principal), rateOperand("aliquota adicional", cfg.AdditionalRate)},
+			Description: fmt.Sprintf("Saldo %s × %s", formatBRL(principal), formatPercent(cfg.AdditionalRate)),
+			Exact:       formatExact(domain.RateDenominator, int64(principal), int64(cfg.AdditionalRate)),
+			Rounding:    roundingName(cfg.Rounding),
+			Result:      additional,
+		},
+	}
+
+	if daily+additional > maxVal {
+		steps = append(steps, Step{
+			Name:        "Teto do IOF",
+			Formula:     "saldo × teto anual",
+			Operands:    []Operand{moneyOperand("saldo", principal), rateOperand("teto anual", cfg.MaxAnnualRate)},
+			Description: fmt.Sprintf("Saldo %s × %s", formatBRL(principal), formatPercent(cfg.MaxAnnualRate)),
+			Exact:       formatExact(domain.RateDenominator, int64(principal), int64(cfg.MaxAnnualRate)),
+			Rounding:    roundingName(cfg.Rounding),
+			Capped:      true,
+			Result:      maxVal,
+		})
+	}
+
+	steps = append(steps, Step{
+		Name:        "IOF total",
+		Formula:     "min(IOF diario + IOF adicional, teto)",
+		Description: fmt.Sprintf("%s + %s", formatBRL(daily), formatBRL(additional)),
+		Capped:      daily+additional > maxVal,
+		Result:      iof,
+	})
+	return steps, iof
+}
+
+func iofCapped(principal domain.Money, days int, cfg config.IOFConfig) bool {
+	return mulRateDays(principal, cfg.DailyRate, days, cfg.Rounding)+mulRate(principal, cfg.AdditionalRate, cfg.Rounding) > mulRate(principal, cfg.MaxAnnualRate, cfg.Rounding)
+}
+
+func moneyOperand(name string, v domain.Money) Operand {
+	return Operand{Name: name, Kind: OperandMoney, Value: int64(v)}
+}
+
+func rateOperand(name string, v domain.Rate) Operand {
+	return Operand{Name: name, Kind: OperandRate, Value: int64(v)}
+}
+
+func daysOperand(name string, v int) Operand {
+	return Operand{Name: name, Kind: OperandDays, Value: int64(v)}
+}
+
+func countOperand(name string, v int) Operand {
+	return Operand{Name: name, Kind: OperandCount, Value: int64(v)}
+}
+
+func roundingLabel(mode string) string {
+	switch mode {
+	case string(domain.RoundHalfUp):
+		return "meio para cima"
+	case string(domain.RoundHalfEven):
+		return "meio para o par"
+	case string(domain.RoundDown):
+		return "truncado"
+	case "down":
+		return "para baixo"
+	default:
+		return mode
+	}
+}
+
+// formatBRL formats centavos as Brazilian currency, e.g. "R$ 1.000,00".
+func formatBRL(v domain.Money) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%sR$ %s,%02d", sign, groupThousands(int64(v)/100), int64(v)%100)
+}
+
+// formatExact formats the product of factors divided by den, in centavos, in
+// reais with 6 decimals (truncated), e.g. "R$ 100,004166". Like roundProduct
+// the product is exact, so large balances do not overflow.
+func formatExact(den int64, factors ...int64) string {
+	num := big.NewInt(1)
+	for _, f := range factors {
+		num.Mul(num, big.NewInt(f))
+	}
+	return formatExactBig(num, big.NewInt(den))
+}
+
+// formatExactBig formats the non-negative centavo fraction num/den like
+// formatExact, e.g. the exact PMT.
+func formatExactBig(num, den *big.Int) string {
+	reais, frac := new(big.Int).QuoRem(num, new(big.Int).Mul(den, big.NewInt(100)), new(big.Int))
+	frac.Mul(frac, big.NewInt(1_000_000)).Quo(frac, new(big.Int).Mul(den, big.NewInt(100)))
+	return fmt.Sprintf("R$ %s,%06d", groupThousands(reais.Int64()), frac.Int64())
+}
+
+// formatDecimal formats the non-negative fraction num/den with digits
+// decimals (truncated), e.g. "1,268241794562".
+func formatDecimal(num, den *big.Int, digits int) string {
+	whole, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	rem.Mul(rem, scale).Quo(rem, den)
+	return fmt.Sprintf("%s,%0*d", whole, digits, rem)
+}
+
+// formatPercent formats a rate as a percentage, e.g. Rate(120_000) -> "12%"
+// and Rate(82) -> "0,0082%".
+func formatPercent(r domain.Rate) string {
+	sign := ""
+	if r < 0 {
+		sign = "-"
+		r = -r
+	}
+	whole := int64(r) / 10_000
+	frac := strings.TrimRight(fmt.Sprintf("%04d", int64(r)%10_000), "0")
+	if frac == "" {
+		return fmt.Sprintf("%s%d%%", sign, whole)
+	}
+	return fmt.Sprintf("%s%d,%s%%", sign, whole, frac)
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("02/01/2006")
+}
+
+func groupThousands(v int64) string {
+	s := fmt.Sprintf("%d", v)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "." + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,209 @@
+package calc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestExplainRotative_MatchesResult(t *testing.T) {
+	balance := domain.RotativeBalance{Principal: 100_000, StartDate: utcDate(2024, 1, 1)}
+
+	exp := ExplainRotative(
+		balance,
+		utcDate(2024, 1, 26),
+		defaultIOFConfig(),
+		defaultInterestConfig(),
+		defaultLateFeeConfig(),
+		defaultLateInterestConfig(),
+		defaultRotativeRulesConfig(),
+	)
+	result := CalculateRotative(
+		balance,
+		utcDate(2024, 1, 26),
+		defaultIOFConfig(),
+		defaultInterestConfig(),
+		defaultLateFeeConfig(),
+		defaultLateInterestConfig(),
+		defaultRotativeRulesConfig(),
+	)
+
+	if exp.Result != result.Total {
+		t.Fatalf("expected explanation result %d, got %d", result.Total, exp.Result)
+	}
+
+	text := exp.Text()
+	want := "Juros rotativo: Saldo R$ 1.000,00 × 12%/30 × 25 dias = R$ 100,00"
+	if !strings.Contains(text, want) {
+		t.Fatalf("expected text to contain %q, got:\n%s", want, text)
+	}
+}
+
+func TestExplainRotative_ReportsCap(t *testing.T) {
+	balance := domain.RotativeBalance{Principal: 100_000, StartDate: utcDate(2024, 1, 1)}
+
+	exp := ExplainRotative(
+		balance,
+		utcDate(2024, 1, 31),
+		defaultIOFConfig(),
+		config.InterestConfig{MonthlyRate: 1_000_000},
+		defaultLateFeeConfig(),
+		defaultLateInterestConfig(),
+		defaultRotativeRulesConfig(),
+	)
+
+	capped := false
+	for _, s := range exp.Steps {
+		if s.Name == "Juros rotativo apos teto" && s.Capped {
+			capped = true
+		}
+	}
+	if !capped {
+		t.Fatalf("expected a capped interest step, got %+v", exp.Steps)
+	}
+	if exp.Result != 200_000 {
+		t.Fatalf("expected capped total 200000, got %d", exp.Result)
+	}
+}
+
+func TestExplainIOF_StepsAndJSON(t *testing.T) {
+	exp := ExplainIOF(100_000, 30, defaultIOFConfig())
+
+	if exp.Result != CalculateIOF(100_000, 30, defaultIOFConfig()) {
+		t.Fatalf("explanation result differs from CalculateIOF")
+	}
+	if len(exp.Steps) != 3 {
+		t.Fatalf("expected daily, additional and total steps, got %d", len(exp.Steps))
+	}
+
+	b, err := json.Marshal(exp)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded Explanation
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.Steps[0].Operands[1].Kind != OperandRate || decoded.Steps[0].Operands[1].Value != 82 {
+		t.Fatalf("expected daily rate operand, got %+v", decoded.Steps[0].Operands[1])
+	}
+}
+
+func TestExplainRotative_ExactAtMaxAmount(t *testing.T) {
+	balance := domain.RotativeBalance{Principal: MaxAmount, StartDate: utcDate(2024, 1, 1)}
+	exp := ExplainRotative(balance, utcDate(2024, 1, 31), defaultIOFConfig(), defaultInterestConfig(),
+		defaultLateFeeConfig(), defaultLateInterestConfig(), defaultRotativeRulesConfig())
+
+	// principal × rate × days overflows int64; the exact term must still match
+	// the charged interest.
+	for _, step := range exp.Steps {
+		if step.Name == "Juros rotativo" {
+			if want := "R$ 1.200.000.000.000,000000"; step.Exact != want || step.Result != 120_000_000_000_000 {
+				t.Fatalf("expected exact %s for %d, got %s", want, step.Result, step.Exact)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected an interest step, got:\n%s", exp.Text())
+}
+
+func TestExplainInstallmentPlan_TotalsMatchPlan(t *testing.T) {
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900}
+	exp := ExplainInstallmentPlan(100_000, 12, utcDate(2024, 1, 5), utcDate(2024, 2, 10), defaultIOFConfig(), instCfg)
+	plan := CalculateInstallmentPlan(100_000, 12, utcDate(2024, 1, 5), utcDate(2024, 2, 10), defaultIOFConfig(), instCfg)
+
+	if exp.Result != plan.TotalWithIOF {
+		t.Fatalf("expected result %d, got %d", plan.TotalWithIOF, exp.Result)
+	}
+	if !strings.Contains(exp.Text(), "Parcela 12 (10/01/2025) - total") {
+		t.Fatalf("expected a line for the last installment, got:\n%s", exp.Text())
+	}
+}
+
+func TestExplainInstallmentPlan_ExactPower(t *testing.T) {
+	// 72x at 20% a.m.: (1+i)^n is shown from the exact power, with more
+	// decimals than a Rate holds.
+	instCfg := config.InstallmentConfig{MonthlyRate: 200_000}
+	exp := ExplainInstallmentPlan(1_000_000, 72, utcDate(2024, 1, 5), utcDate(2024, 2, 5), defaultIOFConfig(), instCfg)
+	plan := CalculateInstallmentPlan(1_000_000, 72, utcDate(2024, 1, 5), utcDate(2024, 2, 5), defaultIOFConfig(), instCfg)
+
+	for _, step := range exp.Steps {
+		if step.Name != "Parcela (Tabela Price)" {
+			continue
+		}
+		if !strings.HasPrefix(step.Description, "(1+20%)^72 = 502400,097982381769;") {
+			t.Fatalf("expected the exact power in the description, got %q", step.Description)
+		}
+		// R$ 10.000,00 × 20% × B / (B − 1), just above R$ 2.000,00.
+		if !strings.HasPrefix(step.Exact, "R$ 2.000,00") || step.Result != plan.Installments[0].Principal+plan.Installments[0].Interest {
+			t.Fatalf("expected the exact PMT behind %d, got %q", step.Result, step.Exact)
+		}
+		return
+	}
+	t.Fatalf("expected a PMT step, got:\n%s", exp.Text())
+}
+
+func TestExplainInstallmentPlan_FinancedIOF(t *testing.T) {
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900, FinanceIOF: true}
+	exp := ExplainInstallmentPlan(100_000, 10, utcDate(2024, 1, 5), utcDate(2024, 2, 10), defaultIOFConfig(), instCfg)
+	plan := CalculateInstallmentPlan(100_000, 10, utcDate(2024, 1, 5), utcDate(2024, 2, 10), defaultIOFConfig(), instCfg)
+
+	if exp.Result != plan.TotalWithIOF {
+		t.Fatalf("expected result %d, got %d", plan.TotalWithIOF, exp.Result)
+	}
+	if !strings.Contains(exp.Text(), "IOF financiado: R$ 18,67 incorporado ao valor financiado (R$ 1.018,67)") {
+		t.Fatalf("expected the financed IOF note, got:\n%s", exp.Text())
+	}
+	for _, step := range exp.Steps {
+		if step.Name == "Parcela (Tabela Price)" && step.Result != plan.Installments[0].Amount {
+			t.Fatalf("expected PMT %d, got %d", plan.Installments[0].Amount, step.Result)
+		}
+		if step.Name == "Parcela 1 (10/02/2024) - IOF" {
+			first := plan.Installments[0]
+			if step.Operands[0].Value != int64(first.Principal+first.IOF) {
+				t.Fatalf("expected IOF over the amortization %d, got %d", first.Principal+first.IOF, step.Operands[0].Value)
+			}
+		}
+	}
+}
+
+func TestExplainInstallmentPlan_ShortFirstPeriod(t *testing.T) {
+	instCfg := config.InstallmentConfig{MonthlyRate: 30_000, GracePeriod: true}
+	exp := ExplainInstallmentPlan(100_000, 6, utcDate(2024, 1, 5), utcDate(2024, 1, 25), defaultIOFConfig(), instCfg)
+	plan := CalculateInstallmentPlan(100_000, 6, utcDate(2024, 1, 5), utcDate(2024, 1, 25), defaultIOFConfig(), instCfg)
+
+	var discounted bool
+	for _, step := range exp.Steps {
+		if step.Name == "Desconto do 1o periodo" {
+			discounted = step.Result == plan.GraceInterest
+		}
+		// The balance shown for later parcels must be the one they pay
+		// interest on.
+		if step.Name == "Parcela 2 (25/02/2024) - juros" {
+			if got := mulRate(domain.Money(step.Operands[0].Value), 30_000, instCfg.Rounding); got != step.Result {
+				t.Fatalf("expected interest %d on balance %d, got %d", got, step.Operands[0].Value, step.Result)
+			}
+		}
+	}
+	if !discounted {
+		t.Fatalf("expected a discount step of %d, got:\n%s", plan.GraceInterest, exp.Text())
+	}
+}
+
+func TestFormatHelpers(t *testing.T) {
+	if got := formatBRL(123_456_789); got != "R$ 1.234.567,89" {
+		t.Fatalf("formatBRL: got %q", got)
+	}
+	if got := formatPercent(82); got != "0,0082%" {
+		t.Fatalf("formatPercent: got %q", got)
+	}
+	if got := formatPercent(19_900); got != "1,99%" {
+		t.Fatalf("formatPercent: got %q", got)
+	}
+	if got := formatExact(30*domain.RateDenominator, 100_000, 120_000, 25); got != "R$ 100,000000" {
+		t.Fatalf("formatExact: got %q", got)
+	}
+}
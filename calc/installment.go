@@ -1,22 +1,13 @@
 package calc
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/thiagozs/go-calc-charges-engine/config"
 	"github.com/thiagozs/go-calc-charges-engine/domain"
 )
 
-// fixedPow computes (1 + rate/RateDenominator)^n in fixed point with denominator = RateDenominator.
-func fixedPow(rate domain.Rate, n int) int64 {
-	result := domain.RateDenominator
-	base := domain.RateDenominator + int64(rate)
-	for i := 0; i < n; i++ {
-		result = (result*base + domain.RateDenominator/2) / domain.RateDenominator
-	}
-	return result
-}
-
 // CalculateInstallmentPlan generates an installment plan for a credit card purchase.
 //
 // Parameters:
@@ -25,7 +16,7 @@ func fixedPow(rate domain.Rate, n int) int64 {
 //   - purchaseDate: date of purchase
 //   - firstDueDate: due date of the first installment
 //   - iofCfg: IOF configuration for per-installment IOF calculation
-//   - instCfg: installment interest rate config (MonthlyRate 0 = sem juros)
+//   - instCfg: installment interest rate config (MonthlyRate 0 = sem juros), funding mode and IOF financing
 //
 // Input validation (positive amount, valid dates, n >= 1) is the caller's responsibility.
 func CalculateInstallmentPlan(
@@ -38,37 +29,147 @@ func CalculateInstallmentPlan(
 ) domain.InstallmentPlan {
 	installments := make([]domain.Installment, numInstallments)
 
+	var plan domain.InstallmentPlan
+	funding := instCfg.Funding
+	switch {
+	case funding == domain.FundingMerchant:
+		plan = calculateMerchantFunded(totalAmount, numInstallments, purchaseDate, firstDueDate, instCfg.Remainder, installments)
+	case instCfg.FinanceIOF:
+		plan = calculateFinancedIOF(totalAmount, numInstallments, purchaseDate, firstDueDate, iofCfg, instCfg)
+	default:
+		plan = calculateIssuerFunded(totalAmount, numInstallments, purchaseDate, firstDueDate, iofCfg, instCfg, installments)
+	}
+
+	if funding == "" {
+		funding = domain.FundingIssuer
+	}
+	plan.Funding = funding
+	for i := range plan.Installments {
+		plan.Installments[i].Funding = funding
+	}
+	return plan
+}
+
+// calculateIssuerFunded computes a parcelado emissor plan with the IOF of
+// each installment added on top of it.
+func calculateIssuerFunded(
+	totalAmount domain.Money,
+	n int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+	installments []domain.Installment,
+) domain.InstallmentPlan {
 	if instCfg.MonthlyRate == 0 {
-		return calculateInterestFree(totalAmount, numInstallments, purchaseDate, firstDueDate, iofCfg, installments)
+		return calculateInterestFree(totalAmount, n, purchaseDate, firstDueDate, iofCfg, instCfg.Remainder, installments)
+	}
+	return calculateWithInterest(totalAmount, n, purchaseDate, firstDueDate, iofCfg, instCfg, installments)
+}
+
+// maxGrossUpIterations bounds the IOF gross-up. Each iteration adds the IOF of
+// the previous increment, a few percent of it, so a handful suffice.
+const maxGrossUpIterations = 64
+
+// calculateFinancedIOF computes a parcelado emissor plan with the IOF financed
+// (IOF financiado): the IOF is computed upfront and added to the financed
+// amount, so parcels are equal and carry no IOF on top.
+//
+// The financed amount F is grossed up until it covers the IOF of its own
+// amortization schedule: F = totalAmount + IOF(F), iterated from
+// F = totalAmount until the IOF no longer grows. Each installment then splits
+// its amortization into the IOF of that amortization and the purchase
+// principal, so principals still sum to totalAmount and Amount is the equal
+// parcel. Rounding can stop the iteration a centavo or so above the IOF of F;
+// the last installment reports that difference as IOF (spilling to the ones
+// before it on tiny amortizations).
+func calculateFinancedIOF(
+	totalAmount domain.Money,
+	n int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+) domain.InstallmentPlan {
+	financed := totalAmount
+	plan := calculateIssuerFunded(financed, n, purchaseDate, firstDueDate, iofCfg, instCfg, make([]domain.Installment, n))
+	for range maxGrossUpIterations {
+		next := totalAmount + plan.TotalIOF
+		if next <= financed {
+			break
+		}
+		financed = next
+		plan = calculateIssuerFunded(financed, n, purchaseDate, firstDueDate, iofCfg, instCfg, make([]domain.Installment, n))
+	}
+
+	financedIOF := financed - totalAmount
+	extra := financedIOF - plan.TotalIOF
+	for i := n - 1; extra > 0 && i >= 0; i-- {
+		inst := &plan.Installments[i]
+		add := min(extra, inst.Principal-inst.IOF)
+		inst.IOF += add
+		extra -= add
+	}
+	for i := range plan.Installments {
+		inst := &plan.Installments[i]
+		inst.Principal -= inst.IOF
+		inst.Amount = inst.Principal + inst.Interest + inst.IOF
 	}
-	return calculateWithInterest(totalAmount, numInstallments, purchaseDate, firstDueDate, iofCfg, instCfg, installments)
+
+	plan.TotalAmount = totalAmount
+	plan.TotalIOF = financedIOF
+	plan.TotalWithIOF = totalAmount + plan.TotalInterest + financedIOF
+	plan.IOFFinanced = true
+	return plan
+}
+
+// calculateMerchantFunded computes a parcelado lojista plan. The merchant funds
+// the plan, so the cardholder pays neither interest nor IOF; the principal is
+// split as in an interest-free plan.
+func calculateMerchantFunded(
+	totalAmount domain.Money,
+	n int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	remainderAt domain.RemainderPlacement,
+	installments []domain.Installment,
+) domain.InstallmentPlan {
+	return calculateInterestFree(totalAmount, n, purchaseDate, firstDueDate, config.IOFConfig{}, remainderAt, installments)
 }
 
 // calculateInterestFree computes an interest-free installment plan (sem juros).
-// Principal is divided equally; remainder centavos go to the first installment.
+// Principal is divided equally; remainder centavos go to the first installment,
+// or to the last one with RemainderLast.
 func calculateInterestFree(
 	totalAmount domain.Money,
 	n int,
 	purchaseDate time.Time,
 	firstDueDate time.Time,
 	iofCfg config.IOFConfig,
+	remainderAt domain.RemainderPlacement,
 	installments []domain.Installment,
 ) domain.InstallmentPlan {
 	base := totalAmount / domain.Money(n)
 	remainder := totalAmount - base*domain.Money(n)
+	remainderIdx := 0
+	if remainderAt == domain.RemainderLast {
+		remainderIdx = n - 1
+	}
 
 	var totalIOF domain.Money
+	var residue int64
 	for i := 0; i < n; i++ {
 		dueDate := addMonths(firstDueDate, i)
 		days := daysBetween(purchaseDate, dueDate)
 
 		principal := base
-		if i == 0 {
+		if i == remainderIdx {
 			principal += remainder
 		}
 
-		iof := CalculateIOF(principal, days, iofCfg)
+		iof, iofResidue := creditIOF(principal, days, iofCfg)
 		totalIOF += iof
+		residue += iofResidue
 
 		installments[i] = domain.Installment{
 			Number:    i + 1,
@@ -81,15 +182,28 @@ func calculateInterestFree(
 	}
 
 	return domain.InstallmentPlan{
-		TotalAmount:   totalAmount,
-		TotalIOF:      totalIOF,
-		TotalInterest: 0,
-		TotalWithIOF:  totalAmount + totalIOF,
-		Installments:  installments,
+		TotalAmount:     totalAmount,
+		TotalIOF:        totalIOF,
+		TotalInterest:   0,
+		TotalWithIOF:    totalAmount + totalIOF,
+		Installments:    installments,
+		RoundingResidue: residue,
 	}
 }
 
 // calculateWithInterest computes an installment plan using Tabela Price (PMT formula).
+//
+// With instCfg.GracePeriod, the interest of the days between purchase and
+// first due date beyond one regular 30-day period is capitalized into the
+// financed amount before the PMT is computed. That capitalized interest is
+// amortized first and reported as interest, so principals still sum to
+// totalAmount. A first period shorter than 30 days discounts the financed
+// amount instead; the discount is taken off the interest of the first parcels
+// and added to their principal.
+//
+// The rounding residue of the plan compares the parcels, without IOF, with n
+// exact PMTs; the last parcel absorbs the rounding of the amortization so the
+// balance reaches zero.
 func calculateWithInterest(
 	totalAmount domain.Money,
 	n int,
@@ -101,36 +215,56 @@ func calculateWithInterest(
 ) domain.InstallmentPlan {
 	r := instCfg.MonthlyRate
 
-	// PMT = PV * r * (1+r)^n / ((1+r)^n - 1)
-	pow := fixedPow(r, n)
-	pmtNum := int64(r) * pow
-	pmtDen := (pow - domain.RateDenominator) * domain.RateDenominator
+	mode := instCfg.Rounding
+
+	var graceInterest domain.Money
+	var residue int64
+	if instCfg.GracePeriod {
+		graceInterest, residue = gracePeriodInterest(totalAmount, r, daysBetween(purchaseDate, firstDueDate), mode)
+	}
+	financed := totalAmount + graceInterest
 
-	pmt := domain.Money((int64(totalAmount)*pmtNum + pmtDen/2) / pmtDen)
+	pmtNum, pmtDen := pricePMTExact(financed, r, n)
+	pmt := domain.Money(quoRound(pmtNum, pmtDen, mode).Int64())
 
-	balance := totalAmount
+	balance := financed
+	pendingGrace := graceInterest
 	var totalInterest, totalIOF domain.Money
 
 	for i := range n {
 		dueDate := addMonths(firstDueDate, i)
 		days := daysBetween(purchaseDate, dueDate)
 
-		interest := mulRate(balance, r)
-		principal := pmt - interest
+		interest := mulRate(balance, r, mode)
+		// On tiny amounts the rounded PMT can amortize the balance before the
+		// last parcel; it never goes below zero.
+		amortization := min(pmt-interest, balance)
 
 		// Last installment: adjust for rounding to ensure
 		// balance reaches zero
 		if i == n-1 {
-			principal = balance
-			interest = pmt - principal
+			amortization = balance
+			interest = pmt - amortization
 			if interest < 0 {
-				interest = mulRate(balance, r)
-				principal = balance
+				interest = mulRate(balance, r, mode)
 			}
 		}
 
-		iof := CalculateIOF(principal, days, iofCfg)
+		// Capitalized grace interest is paid off before the purchase principal;
+		// a short first period discount comes off the interest.
+		var fromGrace domain.Money
+		if pendingGrace > 0 {
+			fromGrace = min(amortization, pendingGrace)
+		} else {
+			fromGrace = -min(-pendingGrace, interest)
+		}
+		pendingGrace -= fromGrace
+		principal := amortization - fromGrace
+		interest += fromGrace
+
+		iof, iofResidue := creditIOF(principal, days, iofCfg)
 		totalIOF += iof
+		residue += iofResidue
 		totalInterest += interest
 
 		installments[i] = domain.Installment{
@@ -142,14 +276,54 @@ func calculateWithInterest(
 			Amount:    principal + interest + iof,
 		}
 
-		balance -= principal
+		balance -= amortization
 	}
 
+	// Parcels without IOF sum to financed + interest beyond the grace interest.
+	paid := big.NewInt(int64(financed + totalInterest - graceInterest))
+	residue += bigResidue(paid, new(big.Int).Mul(pmtNum, big.NewInt(int64(n))), pmtDen)
+
 	return domain.InstallmentPlan{
-		TotalAmount:   totalAmount,
-		TotalIOF:      totalIOF,
-		TotalInterest: totalInterest,
-		TotalWithIOF:  totalAmount + totalInterest + totalIOF,
-		Installments:  installments,
+		TotalAmount:     totalAmount,
+		TotalIOF:        totalIOF,
+		TotalInterest:   totalInterest,
+		TotalWithIOF:    totalAmount + totalInterest + totalIOF,
+		GraceInterest:   graceInterest,
+		Installments:    installments,
+		RoundingResidue: residue,
 	}
 }
+
+// gracePeriodInterest returns the interest of the first period days beyond one
+// regular 30-day period: whole months compound at the monthly rate and the
+// remaining days accrue pro rata (exponential-linear convention). It also
+// returns the residue of both roundings.
+//
+// A first period of d < 30 days returns a negative amount, the discount
+// PV × (1 + i × d/30) / (1+i) − PV: a Price plan of the discounted amount
+// leaves, after its first parcel, the balance of PV with d days of interest.
+func gracePeriodInterest(pv domain.Money, r domain.Rate, firstPeriodDays int, mode domain.RoundingMode) (domain.Money, int64) {
+	extra := firstPeriodDays - 30
+	if extra == 0 || r == 0 {
+		return 0, 0
+	}
+	if extra < 0 {
+		days := int64(max(firstPeriodDays, 0))
+		discounted, residue := roundProduct(30*(domain.RateDenominator+int64(r)), mode,
+			int64(pv), 30*domain.RateDenominator+int64(r)*days)
+		// Rounding on tiny amounts must not leave the first interest negative.
+		if floor := pv - mulRate(discounted, r, mode); discounted < floor {
+			residue += int64(floor-discounted) * domain.ResidueDenominator
+			discounted = floor
+		}
+		return discounted - pv, residue
+	}
+
+	months, days := extra/30, extra%30
+	capitalized, residue := compound(pv, r, months, mode)
+
+	denom := int64(30) * domain.RateDenominator
+	proRata, proRataResidue := roundProduct(denom, mode, int64(capitalized), int64(r), int64(days))
+
+	return capitalized + proRata - pv, residue + proRataResidue
+}
@@ -184,3 +184,232 @@ func TestInstallment_DueDateProgression(t *testing.T) {
 		}
 	}
 }
+
+func TestInstallment_MerchantFunded_NoInterestNoIOF(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	firstDueDate := utcDate(2024, 2, 10)
+	iofCfg := defaultIOFConfig()
+	// Rate is ignored: the merchant funds the plan.
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900, Funding: domain.FundingMerchant}
+
+	plan := CalculateInstallmentPlan(100_000, 10, purchaseDate, firstDueDate, iofCfg, instCfg)
+
+	if plan.Funding != domain.FundingMerchant {
+		t.Fatalf("expected merchant funding, got %q", plan.Funding)
+	}
+	if plan.TotalInterest != 0 || plan.TotalIOF != 0 {
+		t.Fatalf("expected no interest and no IOF, got interest %d IOF %d", plan.TotalInterest, plan.TotalIOF)
+	}
+	if plan.TotalWithIOF != 100_000 {
+		t.Fatalf("expected total 100000, got %d", plan.TotalWithIOF)
+	}
+	for i, inst := range plan.Installments {
+		if inst.Amount != 10_000 || inst.Funding != domain.FundingMerchant {
+			t.Fatalf("installment %d: expected 10000 merchant-funded, got %d %q", i+1, inst.Amount, inst.Funding)
+		}
+	}
+}
+
+func TestInstallment_DefaultFundingIsIssuer(t *testing.T) {
+	plan := CalculateInstallmentPlan(100_000, 3, utcDate(2024, 1, 5), utcDate(2024, 2, 10),
+		defaultIOFConfig(), config.InstallmentConfig{MonthlyRate: 0})
+
+	if plan.Funding != domain.FundingIssuer || plan.Installments[0].Funding != domain.FundingIssuer {
+		t.Fatalf("expected issuer funding by default, got %q", plan.Funding)
+	}
+	if plan.TotalIOF <= 0 {
+		t.Fatalf("expected issuer plan to carry IOF")
+	}
+}
+
+func TestInstallment_GracePeriod_CapitalizesFirstPeriodInterest(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	// First payment in 60 days: one month of carencia beyond the regular period.
+	firstDueDate := utcDate(2024, 3, 5)
+	iofCfg := defaultIOFConfig()
+
+	regular := CalculateInstallmentPlan(100_000, 12, purchaseDate, firstDueDate, iofCfg,
+		config.InstallmentConfig{MonthlyRate: 19_900})
+	grace := CalculateInstallmentPlan(100_000, 12, purchaseDate, firstDueDate, iofCfg,
+		config.InstallmentConfig{MonthlyRate: 19_900, GracePeriod: true})
+
+	// 100000 × 1.0199 − 100000
+	if grace.GraceInterest != 1_990 {
+		t.Fatalf("expected grace interest 1990, got %d", grace.GraceInterest)
+	}
+	if regular.GraceInterest != 0 {
+		t.Fatalf("expected no grace interest when disabled, got %d", regular.GraceInterest)
+	}
+	if grace.TotalInterest <= regular.TotalInterest {
+		t.Fatalf("expected grace interest to raise total interest: %d vs %d", grace.TotalInterest, regular.TotalInterest)
+	}
+
+	var principalSum domain.Money
+	for i, inst := range grace.Installments {
+		principalSum += inst.Principal
+		if inst.Principal < 0 || inst.Interest < 0 {
+			t.Fatalf("installment %d: negative component %+v", i+1, inst)
+		}
+		if i < 11 && inst.Principal+inst.Interest != grace.Installments[0].Principal+grace.Installments[0].Interest {
+			t.Fatalf("installment %d: expected constant PMT", i+1)
+		}
+	}
+	if principalSum != 100_000 {
+		t.Fatalf("sum of principals: expected 100000, got %d", principalSum)
+	}
+}
+
+func TestInstallment_GracePeriod_ProRataDays(t *testing.T) {
+	// 45 days to the first due date: 15 days beyond the regular period.
+	plan := CalculateInstallmentPlan(100_000, 6, utcDate(2024, 1, 5), utcDate(2024, 2, 19),
+		defaultIOFConfig(), config.InstallmentConfig{MonthlyRate: 30_000, GracePeriod: true})
+
+	// 100000 × 3% × 15/30
+	if plan.GraceInterest != 1_500 {
+		t.Fatalf("expected grace interest 1500, got %d", plan.GraceInterest)
+	}
+}
+
+func TestInstallment_GracePeriod_RegularFirstPeriod(t *testing.T) {
+	// A first period of exactly 30 days has no carencia.
+	plan := CalculateInstallmentPlan(100_000, 6, utcDate(2024, 1, 5), utcDate(2024, 2, 4),
+		defaultIOFConfig(), config.InstallmentConfig{MonthlyRate: 30_000, GracePeriod: true})
+
+	if plan.GraceInterest != 0 {
+		t.Fatalf("expected no grace interest, got %d", plan.GraceInterest)
+	}
+}
+
+func TestInstallment_GracePeriod_ShortFirstPeriodDiscounts(t *testing.T) {
+	// 20 days to the first due date: 10 days short of the regular period.
+	instCfg := config.InstallmentConfig{MonthlyRate: 30_000, GracePeriod: true}
+	plan := CalculateInstallmentPlan(100_000, 6, utcDate(2024, 1, 5), utcDate(2024, 1, 25), defaultIOFConfig(), instCfg)
+	instCfg.GracePeriod = false
+	regular := CalculateInstallmentPlan(100_000, 6, utcDate(2024, 1, 5), utcDate(2024, 1, 25), defaultIOFConfig(), instCfg)
+
+	// 100000 × (1 + 3% × 20/30) / 1.03 − 100000
+	if plan.GraceInterest != -971 {
+		t.Fatalf("expected grace discount -971, got %d", plan.GraceInterest)
+	}
+	// 100000 × 3% × 20/30
+	if got := plan.Installments[0].Interest; got != 2_000 {
+		t.Fatalf("expected first interest 2000, got %d", got)
+	}
+	if plan.TotalInterest >= regular.TotalInterest {
+		t.Fatalf("expected a short first period to lower total interest: %d vs %d", plan.TotalInterest, regular.TotalInterest)
+	}
+
+	var principalSum domain.Money
+	for i, inst := range plan.Installments {
+		principalSum += inst.Principal
+		if i < 5 && inst.Principal+inst.Interest != plan.Installments[0].Principal+plan.Installments[0].Interest {
+			t.Fatalf("installment %d: expected constant PMT", i+1)
+		}
+	}
+	if principalSum != 100_000 {
+		t.Fatalf("sum of principals: expected 100000, got %d", principalSum)
+	}
+}
+
+func TestInstallment_InterestFree_RemainderInLast(t *testing.T) {
+	instCfg := config.InstallmentConfig{Remainder: domain.RemainderLast}
+
+	plan := CalculateInstallmentPlan(100_000, 3, utcDate(2024, 1, 5), utcDate(2024, 2, 5), defaultIOFConfig(), instCfg)
+
+	want := []domain.Money{33_333, 33_333, 33_334}
+	for i, inst := range plan.Installments {
+		if inst.Principal != want[i] {
+			t.Fatalf("installment %d: expected principal %d, got %d", i+1, want[i], inst.Principal)
+		}
+	}
+}
+
+func TestInstallment_RoundingModeAndResidue(t *testing.T) {
+	purchase, firstDue := utcDate(2024, 1, 5), utcDate(2024, 2, 5)
+	plan := func(mode domain.RoundingMode) domain.InstallmentPlan {
+		iofCfg := defaultIOFConfig()
+		iofCfg.Rounding = mode
+		return CalculateInstallmentPlan(1_234_567, 12, purchase, firstDue, iofCfg, config.InstallmentConfig{MonthlyRate: 19_900, Rounding: mode})
+	}
+
+	halfUp, truncated := plan(domain.RoundHalfUp), plan(domain.RoundDown)
+
+	// Truncation never charges more than the exact plan, and never more than
+	// half-up rounding; each rounded amount is off by less than a centavo.
+	if truncated.RoundingResidue > 0 || truncated.TotalWithIOF > halfUp.TotalWithIOF {
+		t.Fatalf("truncate: residue %d, total %d vs half-up %d", truncated.RoundingResidue, truncated.TotalWithIOF, halfUp.TotalWithIOF)
+	}
+	for _, p := range []domain.InstallmentPlan{halfUp, truncated} {
+		if r := p.RoundingResidue; r <= -36*domain.ResidueDenominator || r >= 36*domain.ResidueDenominator {
+			t.Fatalf("residue %d out of bounds", r)
+		}
+		var principal domain.Money
+		for _, inst := range p.Installments {
+			principal += inst.Principal
+		}
+		if principal != 1_234_567 {
+			t.Fatalf("expected principals to sum to 1234567, got %d", principal)
+		}
+	}
+}
+
+func TestInstallment_FinancedIOF_EqualParcels(t *testing.T) {
+	purchase, firstDue := utcDate(2024, 1, 5), utcDate(2024, 2, 10)
+
+	tests := []struct {
+		name          string
+		instCfg       config.InstallmentConfig
+		wantIOF       domain.Money
+		wantInterest  domain.Money
+		wantParcel    domain.Money
+		wantFirstPart domain.Money // first parcel, which takes the remainder of sem juros plans
+	}{
+		{"sem juros", config.InstallmentConfig{FinanceIOF: true}, 1_828, 0, 10_182, 10_190},
+		{"price", config.InstallmentConfig{FinanceIOF: true, MonthlyRate: 19_900}, 1_867, 11_483, 11_335, 11_335},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := CalculateInstallmentPlan(100_000, 10, purchase, firstDue, defaultIOFConfig(), tc.instCfg)
+
+			if !plan.IOFFinanced || plan.TotalIOF != tc.wantIOF || plan.TotalInterest != tc.wantInterest {
+				t.Fatalf("expected financed IOF %d and interest %d, got %+v", tc.wantIOF, tc.wantInterest, plan)
+			}
+			if plan.TotalWithIOF != 100_000+tc.wantInterest+tc.wantIOF {
+				t.Fatalf("unexpected total with IOF %d", plan.TotalWithIOF)
+			}
+
+			var principal, iof domain.Money
+			for i, inst := range plan.Installments {
+				want := tc.wantParcel
+				if i == 0 {
+					want = tc.wantFirstPart
+				}
+				if inst.Amount != want || inst.Amount != inst.Principal+inst.Interest+inst.IOF {
+					t.Fatalf("installment %d: expected amount %d, got %+v", i+1, want, inst)
+				}
+				principal += inst.Principal
+				iof += inst.IOF
+			}
+			if principal != 100_000 || iof != plan.TotalIOF {
+				t.Fatalf("expected principals 100000 and IOF %d, got %d and %d", plan.TotalIOF, principal, iof)
+			}
+
+			// The grossed-up amount covers the IOF of its own amortization.
+			tc.instCfg.FinanceIOF = false
+			gross := CalculateInstallmentPlan(100_000+plan.TotalIOF, 10, purchase, firstDue, defaultIOFConfig(), tc.instCfg)
+			if gross.TotalIOF > plan.TotalIOF {
+				t.Fatalf("IOF of the financed amount %d exceeds the financed IOF %d", gross.TotalIOF, plan.TotalIOF)
+			}
+		})
+	}
+}
+
+func TestInstallment_FinancedIOF_MerchantIgnored(t *testing.T) {
+	instCfg := config.InstallmentConfig{FinanceIOF: true, Funding: domain.FundingMerchant}
+	plan := CalculateInstallmentPlan(100_000, 10, utcDate(2024, 1, 5), utcDate(2024, 2, 10), defaultIOFConfig(), instCfg)
+
+	if plan.IOFFinanced || plan.TotalIOF != 0 || plan.TotalWithIOF != 100_000 {
+		t.Fatalf("expected merchant plan without IOF, got %+v", plan)
+	}
+}
@@ -10,7 +10,11 @@ import (
 //
 // Input validation (non-negative principal, valid days) is the caller's responsibility.
 func CalculateRotativeInterest(principal domain.Money, days int, cfg config.InterestConfig) domain.Money {
-	denom := int64(30) * domain.RateDenominator
-	num := int64(principal) * int64(cfg.MonthlyRate) * int64(days)
-	return domain.Money((num + denom/2) / denom)
+	interest, _ := rotativeInterest(principal, days, cfg)
+	return interest
+}
+
+// rotativeInterest is CalculateRotativeInterest with its rounding residue.
+func rotativeInterest(principal domain.Money, days int, cfg config.InterestConfig) (domain.Money, int64) {
+	return roundProduct(30*domain.RateDenominator, cfg.Rounding, int64(principal), int64(cfg.MonthlyRate), int64(days))
 }
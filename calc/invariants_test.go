@@ -0,0 +1,348 @@
+package calc
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// Property and fuzz tests of the monetary invariants. `go test` runs the
+// seeds; explore with e.g. `go test ./calc -fuzz FuzzCalculateRotative`.
+
+// maxFuzzMoney bounds fuzzed amounts to the largest amount the engine is
+// specified for, so that sums of a few amounts stay far from int64 overflow.
+const maxFuzzMoney = MaxAmount
+
+var fuzzModes = []domain.RoundingMode{domain.RoundHalfUp, domain.RoundHalfEven, domain.RoundDown}
+
+// bounded maps v into [0, limit].
+func bounded(v, limit int64) int64 {
+	if v < 0 {
+		v = -(v + 1)
+	}
+	return v % (limit + 1)
+}
+
+func FuzzApplyPayment(f *testing.F) {
+	f.Add(int64(773), int64(14_815), int64(1_235), int64(2_469), int64(123_457), int64(20_000))
+	f.Add(int64(0), int64(0), int64(0), int64(0), int64(0), int64(0))
+	f.Add(int64(10), int64(20), int64(30), int64(40), int64(50), int64(1_000))
+	f.Add(int64(10), int64(20), int64(30), int64(40), int64(50), int64(-5))
+	f.Add(int64(math.MaxInt64), int64(math.MaxInt64), int64(1), int64(1), int64(1), int64(math.MinInt64))
+
+	f.Fuzz(func(t *testing.T, iof, interest, lateInterest, lateFee, principal, payment int64) {
+		iof, interest = bounded(iof, maxFuzzMoney), bounded(interest, maxFuzzMoney)
+		lateInterest, lateFee = bounded(lateInterest, maxFuzzMoney), bounded(lateFee, maxFuzzMoney)
+		principal = bounded(principal, maxFuzzMoney)
+		payment = bounded(payment, 2*maxFuzzMoney) - maxFuzzMoney
+		total := iof + interest + lateInterest + lateFee + principal
+
+		r := ApplyPayment(domain.Money(total), domain.Money(iof), domain.Money(interest),
+			domain.Money(lateInterest), domain.Money(lateFee), domain.Money(principal), domain.Money(payment))
+
+		buckets := []struct {
+			name       string
+			paid, owed domain.Money
+		}{
+			{"iof", r.PaidIOF, domain.Money(iof)},
+			{"interest", r.PaidInterest, domain.Money(interest)},
+			{"late interest", r.PaidLateInterest, domain.Money(lateInterest)},
+			{"late fee", r.PaidLateFee, domain.Money(lateFee)},
+			{"principal", r.PaidPrincipal, domain.Money(principal)},
+		}
+		var paid domain.Money
+		for _, b := range buckets {
+			if b.paid < 0 || b.paid > b.owed {
+				t.Fatalf("%s: paid %d of %d", b.name, b.paid, b.owed)
+			}
+			paid += b.paid
+		}
+		if want := min(max(domain.Money(payment), 0), domain.Money(total)); paid != want {
+			t.Fatalf("buckets sum to %d, want min(payment, total) = %d", paid, want)
+		}
+		if r.Remaining != domain.Money(total)-paid {
+			t.Fatalf("remaining %d, want %d", r.Remaining, domain.Money(total)-paid)
+		}
+	})
+}
+
+func FuzzCalculateInstallmentPlan(f *testing.F) {
+	f.Add(int64(100_000), uint8(3), uint32(0), uint16(31), uint8(0), false, false, false, false)
+	f.Add(int64(100_000), uint8(3), uint32(0), uint16(31), uint8(2), true, false, false, false)
+	f.Add(int64(1_234_567), uint8(12), uint32(19_900), uint16(31), uint8(1), false, false, false, false)
+	f.Add(int64(60_000), uint8(10), uint32(29_900), uint16(60), uint8(0), false, true, false, false)
+	f.Add(int64(1), uint8(72), uint32(200_000), uint16(120), uint8(2), false, true, false, false)
+	f.Add(int64(999_999), uint8(7), uint32(19_900), uint16(31), uint8(0), true, false, true, false)
+	f.Add(int64(maxFuzzMoney), uint8(48), uint32(99_000), uint16(90), uint8(0), false, true, false, false)
+	f.Add(int64(100_000), uint8(10), uint32(19_900), uint16(36), uint8(0), false, false, false, true)
+	f.Add(int64(100_001), uint8(10), uint32(0), uint16(36), uint8(1), true, true, false, true)
+
+	f.Fuzz(func(t *testing.T, amount int64, n uint8, rate uint32, firstDueDays uint16, mode uint8, last, grace, merchant, financed bool) {
+		amount = bounded(amount, maxFuzzMoney-1) + 1
+		num := int(n%72) + 1
+		iofCfg := defaultIOFConfig()
+		iofCfg.Rounding = fuzzModes[int(mode)%len(fuzzModes)]
+		instCfg := config.InstallmentConfig{
+			MonthlyRate: domain.Rate(rate % 200_001),
+			GracePeriod: grace,
+			Rounding:    iofCfg.Rounding,
+			FinanceIOF:  financed,
+		}
+		if last {
+			instCfg.Remainder = domain.RemainderLast
+		}
+		if merchant {
+			instCfg.Funding = domain.FundingMerchant
+		}
+		purchase := utcDate(2024, 1, 5)
+		firstDue := purchase.AddDate(0, 0, int(firstDueDays%120)+1)
+
+		plan := CalculateInstallmentPlan(domain.Money(amount), num, purchase, firstDue, iofCfg, instCfg)
+
+		if len(plan.Installments) != num {
+			t.Fatalf("expected %d installments, got %d", num, len(plan.Installments))
+		}
+		var principal, interest, iof domain.Money
+		for _, inst := range plan.Installments {
+			if inst.Principal < 0 || inst.Interest < 0 || inst.IOF < 0 {
+				t.Fatalf("negative component in %+v", inst)
+			}
+			if inst.Amount != inst.Principal+inst.Interest+inst.IOF {
+				t.Fatalf("parcel %d: amount %d != principal + interest + IOF", inst.Number, inst.Amount)
+			}
+			days := daysBetween(purchase, inst.DueDate)
+			if !merchant && !financed && inst.IOF != CalculateIOF(inst.Principal, days, iofCfg) {
+				t.Fatalf("parcel %d: IOF %d differs from CalculateIOF", inst.Number, inst.IOF)
+			}
+			principal += inst.Principal
+			interest += inst.Interest
+			iof += inst.IOF
+		}
+		if principal != plan.TotalAmount || plan.TotalAmount != domain.Money(amount) {
+			t.Fatalf("principals sum to %d, TotalAmount %d, want %d", principal, plan.TotalAmount, amount)
+		}
+		if interest != plan.TotalInterest || iof != plan.TotalIOF {
+			t.Fatalf("totals differ from parcels: interest %d/%d IOF %d/%d", interest, plan.TotalInterest, iof, plan.TotalIOF)
+		}
+		if plan.TotalWithIOF != plan.TotalAmount+plan.TotalInterest+plan.TotalIOF {
+			t.Fatalf("TotalWithIOF %d inconsistent", plan.TotalWithIOF)
+		}
+		if (merchant || instCfg.MonthlyRate == 0) && plan.TotalInterest != 0 {
+			t.Fatalf("interest-free plan charged %d", plan.TotalInterest)
+		}
+		if financed && !merchant {
+			checkFinancedIOF(t, plan, purchase, iofCfg, instCfg)
+		}
+	})
+}
+
+// checkFinancedIOF checks a plan with the IOF financed: each parcel's IOF
+// covers the IOF of its grossed-up amortization (principal + IOF), only the
+// gross-up rounding (at most a centavo per parcel) is charged above it, and
+// the parcels are equal (Tabela Price plans too small to amortize a centavo
+// per parcel aside).
+func checkFinancedIOF(t *testing.T, plan domain.InstallmentPlan, purchase time.Time, iofCfg config.IOFConfig, instCfg config.InstallmentConfig) {
+	t.Helper()
+	n := len(plan.Installments)
+	if !plan.IOFFinanced {
+		t.Fatalf("plan not marked IOFFinanced")
+	}
+
+	var due domain.Money
+	for _, inst := range plan.Installments {
+		iof := CalculateIOF(inst.Principal+inst.IOF, daysBetween(purchase, inst.DueDate), iofCfg)
+		if inst.IOF < iof {
+			t.Fatalf("parcel %d: IOF %d below the IOF %d of its amortization", inst.Number, inst.IOF, iof)
+		}
+		due += iof
+	}
+	if plan.TotalIOF-due > domain.Money(n) {
+		t.Fatalf("financed IOF %d exceeds the IOF of the amortizations %d by more than %d", plan.TotalIOF, due, n)
+	}
+
+	// The odd parcel is the remainder parcel of an interest-free plan, or the
+	// last Tabela Price parcel, which absorbs the rounding of every period's
+	// interest (up to a centavo each, compounded) so the balance reaches zero.
+	tolerance := domain.Money(float64(n) * math.Pow(1+float64(instCfg.MonthlyRate)/float64(domain.RateDenominator), float64(n)))
+	odd := n - 1
+	if instCfg.MonthlyRate == 0 && instCfg.Remainder != domain.RemainderLast {
+		odd = 0
+	} else if instCfg.MonthlyRate != 0 && plan.TotalAmount < 100*domain.Money(n) {
+		return
+	}
+	parcel := plan.Installments[(odd+1)%n].Amount
+	for i, inst := range plan.Installments {
+		diff := inst.Amount - parcel
+		if i == odd && diff >= -tolerance && diff <= tolerance {
+			continue
+		}
+		if diff != 0 {
+			t.Fatalf("parcel %d is %d, expected equal parcels of %d", inst.Number, inst.Amount, parcel)
+		}
+	}
+}
+
+func FuzzCalculateRotative(f *testing.F) {
+	f.Add(int64(123_457), uint16(30), uint32(120_000), uint32(20_000), uint32(10_000), uint32(1_000_000), uint8(0))
+	f.Add(int64(123_457), uint16(30), uint32(120_000), uint32(20_000), uint32(10_000), uint32(100_000), uint8(1))
+	f.Add(int64(100_000), uint16(60), uint32(500_000), uint32(100_000), uint32(100_000), uint32(10_000), uint8(2))
+	f.Add(int64(100_000), uint16(0), uint32(120_000), uint32(20_000), uint32(10_000), uint32(1), uint8(0))
+	f.Add(int64(maxFuzzMoney), uint16(365), uint32(500_000), uint32(20_000), uint32(10_000), uint32(0), uint8(0))
+
+	f.Fuzz(func(t *testing.T, principal int64, days uint16, intRate, feeRate, lateRate, maxChargeRate uint32, mode uint8) {
+		p := domain.Money(bounded(principal, maxFuzzMoney))
+		m := fuzzModes[int(mode)%len(fuzzModes)]
+		iofCfg := defaultIOFConfig()
+		iofCfg.Rounding = m
+		intCfg := config.InterestConfig{MonthlyRate: domain.Rate(intRate % 500_001), Rounding: m}
+		feeCfg := config.LateFeeConfig{Rate: domain.Rate(feeRate % 100_001), Rounding: m}
+		lateCfg := config.LateInterestConfig{MonthlyRate: domain.Rate(lateRate % 100_001), Rounding: m}
+		rules := config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: domain.Rate(maxChargeRate % 2_000_001), Rounding: m}
+		start := utcDate(2024, 1, 10)
+		at := start.AddDate(0, 0, int(days%400))
+
+		r := CalculateRotative(domain.RotativeBalance{Principal: p, StartDate: start}, at, iofCfg, intCfg, feeCfg, lateCfg, rules)
+
+		for name, v := range map[string]domain.Money{"interest": r.Interest, "iof": r.IOF, "late fee": r.LateFee, "late interest": r.LateInterest} {
+			if v < 0 {
+				t.Fatalf("negative %s: %d", name, v)
+			}
+		}
+		if r.Charges != r.Interest+r.IOF+r.LateFee+r.LateInterest || r.Total != p+r.Charges {
+			t.Fatalf("inconsistent totals: %+v", r)
+		}
+		if r.IOF != CalculateIOF(p, r.ChargedDays, iofCfg) || r.IOF > mulRate(p, iofCfg.MaxAnnualRate, m) {
+			t.Fatalf("IOF %d exceeds the annual cap", r.IOF)
+		}
+		if r.Interest > CalculateRotativeInterest(p, r.ChargedDays, intCfg) {
+			t.Fatalf("interest %d above the uncapped interest", r.Interest)
+		}
+		if rules.MaxChargeRate > 0 {
+			limit := max(mulRate(p, rules.MaxChargeRate, m), r.IOF)
+			if r.Charges > limit {
+				t.Fatalf("charges %d exceed MaxChargeRate × principal (%d)", r.Charges, limit)
+			}
+		}
+	})
+}
+
+func FuzzCalculateIOF(f *testing.F) {
+	f.Add(int64(100_000), uint16(30), uint8(0))
+	f.Add(int64(100_000), uint16(365), uint8(1))
+	f.Add(int64(1), uint16(1), uint8(2))
+	f.Add(int64(maxFuzzMoney), uint16(1_000), uint8(0))
+
+	f.Fuzz(func(t *testing.T, principal int64, days uint16, mode uint8) {
+		p := domain.Money(bounded(principal, maxFuzzMoney))
+		d := int(days % 1_000)
+		cfg := defaultIOFConfig()
+		cfg.Rounding = fuzzModes[int(mode)%len(fuzzModes)]
+
+		iof := CalculateIOF(p, d, cfg)
+		if iof < 0 || iof > mulRate(p, cfg.MaxAnnualRate, cfg.Rounding) {
+			t.Fatalf("IOF %d outside [0, MaxAnnualRate × principal]", iof)
+		}
+		if next := CalculateIOF(p, d+1, cfg); next < iof {
+			t.Fatalf("IOF decreased from %d to %d with one more day", iof, next)
+		}
+		b := CalculateIOFBreakdown(p, d, cfg)
+		if b.Total != iof || b.Daily+b.Additional != iof || b.Daily < 0 || b.Additional < 0 {
+			t.Fatalf("breakdown %+v does not add up to %d", b, iof)
+		}
+	})
+}
+
+// FuzzRoundProduct checks the 128-bit rounding against big.Rat for the whole
+// int64 range: quotients that do not fit in int64 must panic, never return.
+func FuzzRoundProduct(f *testing.F) {
+	f.Add(int64(2_500_000), int64(1), int64(1), uint32(1_000_000), uint8(0))
+	f.Add(int64(-2_500_000), int64(1), int64(1), uint32(1_000_000), uint8(1))
+	f.Add(int64(math.MaxInt64), int64(1_000_000), int64(1), uint32(1_000_000), uint8(0))
+	f.Add(int64(math.MaxInt64), int64(1_000_001), int64(1), uint32(1_000_000), uint8(2))
+	f.Add(int64(math.MinInt64), int64(1), int64(1), uint32(1), uint8(0))
+	f.Add(int64(10_000_000_000_000), int64(120_000), int64(30), uint32(30_000_000), uint8(0))
+
+	f.Fuzz(func(t *testing.T, a, b, c int64, den uint32, mode uint8) {
+		d := int64(den%30_000_000) + 1
+		m := fuzzModes[int(mode)%len(fuzzModes)]
+
+		exact := new(big.Rat).SetFrac(new(big.Int).Mul(new(big.Int).Mul(big.NewInt(a), big.NewInt(b)), big.NewInt(c)), big.NewInt(d))
+		abs := new(big.Rat).Abs(exact)
+		got, residue, overflow := tryRoundProduct(d, m, a, b, c)
+		switch {
+		case abs.Cmp(new(big.Rat).SetInt64(math.MaxInt64)) <= 0 && overflow:
+			t.Fatalf("%s overflowed", exact.FloatString(3))
+		case abs.Cmp(new(big.Rat).Add(new(big.Rat).SetInt64(math.MaxInt64), big.NewRat(1, 1))) >= 0 && !overflow:
+			t.Fatalf("expected overflow for %s, got %d", exact.FloatString(3), got)
+		case overflow:
+			// Within a centavo of the limit: rounding decides.
+			return
+		}
+		diff := new(big.Rat).Sub(new(big.Rat).SetInt64(int64(got)), exact)
+
+		absDiff := new(big.Rat).Abs(diff)
+		switch m {
+		case domain.RoundDown:
+			if absDiff.Cmp(big.NewRat(1, 1)) >= 0 || new(big.Rat).Abs(new(big.Rat).SetInt64(int64(got))).Cmp(new(big.Rat).Abs(exact)) > 0 {
+				t.Fatalf("truncate(%s) = %d", exact.FloatString(3), got)
+			}
+		default:
+			if absDiff.Cmp(big.NewRat(1, 2)) > 0 {
+				t.Fatalf("%s(%s) = %d", m, exact.FloatString(3), got)
+			}
+		}
+
+		// The residue is the same difference in millionths, within rounding.
+		micro := new(big.Rat).Mul(diff, new(big.Rat).SetInt64(domain.ResidueDenominator))
+		if off := new(big.Rat).Sub(micro, new(big.Rat).SetInt64(residue)); new(big.Rat).Abs(off).Cmp(big.NewRat(1, 2)) > 0 {
+			t.Fatalf("residue %d, want %s", residue, micro.FloatString(3))
+		}
+	})
+}
+
+func TestMoneyMath_OverflowBoundaries(t *testing.T) {
+	// R$ 100 bilhoes at 12% a.m. for 30 days: the product P × rate × days
+	// (3.6e22) does not fit in int64.
+	if got := CalculateRotativeInterest(10_000_000_000_000, 30, defaultInterestConfig()); got != 1_200_000_000_000 {
+		t.Fatalf("expected interest 1200000000000, got %d", got)
+	}
+	if got := mulRate(math.MaxInt64, domain.Rate(domain.RateDenominator), domain.RoundHalfUp); got != math.MaxInt64 {
+		t.Fatalf("expected MaxInt64 × 1 = MaxInt64, got %d", got)
+	}
+	// The largest amount at 100% for 10 years of days fits; beyond int64 panics.
+	if got := mulRateDays(MaxAmount, domain.Rate(domain.RateDenominator), 3_650, domain.RoundHalfUp); got != MaxAmount*3_650 {
+		t.Fatalf("expected %d, got %d", MaxAmount*3_650, got)
+	}
+	for _, p := range []domain.Money{math.MaxInt64, -math.MaxInt64} {
+		if got, _, overflow := tryRoundProduct(domain.RateDenominator, domain.RoundHalfUp, int64(p), 2*domain.RateDenominator); !overflow {
+			t.Fatalf("expected overflow for %d × 2, got %d", p, got)
+		}
+	}
+
+	// The largest fuzzed principal still yields a consistent rotative result.
+	r := CalculateRotative(domain.RotativeBalance{Principal: maxFuzzMoney, StartDate: utcDate(2024, 1, 10)},
+		utcDate(2024, 1, 10).Add(30*24*time.Hour), defaultIOFConfig(), defaultInterestConfig(),
+		defaultLateFeeConfig(), defaultLateInterestConfig(), defaultRotativeRulesConfig())
+	if r.Interest != maxFuzzMoney*12/100 || r.Total != maxFuzzMoney+r.Charges {
+		t.Fatalf("unexpected result at the boundary: %+v", r)
+	}
+}
+
+// tryRoundProduct calls roundProduct and reports whether it panicked with
+// errAmountOverflow.
+func tryRoundProduct(den int64, mode domain.RoundingMode, factors ...int64) (q domain.Money, residue int64, overflow bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errAmountOverflow {
+				panic(r)
+			}
+			overflow = true
+		}
+	}()
+	q, residue = roundProduct(den, mode, factors...)
+	return q, residue, false
+}
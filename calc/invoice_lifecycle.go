@@ -0,0 +1,148 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+var (
+	// ErrIllegalTransition is returned when an event is not allowed in the
+	// current invoice status.
+	ErrIllegalTransition = errors.New("calc: illegal invoice transition")
+	// ErrInvalidPayment is returned for payment events with a non-positive amount.
+	ErrInvalidPayment = errors.New("calc: payment amount must be positive")
+)
+
+// InvoiceEventKind identifies what happened to an invoice.
+type InvoiceEventKind string
+
+const (
+	InvoiceEventClose     InvoiceEventKind = "close"      // billing cycle closed
+	InvoiceEventPayment   InvoiceEventKind = "payment"    // Amount paid on Date
+	InvoiceEventDayPassed InvoiceEventKind = "day_passed" // end of Date
+	InvoiceEventInstall   InvoiceEventKind = "install"    // balance moved to an installment plan
+	InvoiceEventWriteOff  InvoiceEventKind = "write_off"  // balance written off
+)
+
+// InvoiceEvent is an input of the invoice lifecycle.
+type InvoiceEvent struct {
+	Kind   InvoiceEventKind
+	Date   time.Time
+	Amount domain.Money // payments only
+}
+
+// ApplyInvoiceEvent returns inv after ev, or ErrIllegalTransition.
+//
+// Transitions:
+//
+//	open                      --close-->     closed
+//	closed, partially_paid    --payment-->   paid (balance covered) or partially_paid
+//	closed, partially_paid    --day_passed-> after DueDate: paid if nothing is
+//	                                         due, in_rotative if the minimum
+//	                                         was paid, overdue otherwise
+//	overdue, in_rotative      --payment-->   paid when the balance is covered
+//	closed, partially_paid,
+//	overdue, in_rotative      --install-->   installed
+//	overdue, in_rotative      --write_off--> written_off
+//
+// day_passed never fails: it is a no-op when nothing is due. Paid, installed
+// and written off are final. Set inv.MinimumPayment (calc.WithMinimumPayment)
+// before the due date passes.
+func ApplyInvoiceEvent(inv domain.Invoice, ev InvoiceEvent) (domain.Invoice, error) {
+	status := invoiceStatus(inv)
+
+	switch ev.Kind {
+	case InvoiceEventClose:
+		if status != domain.InvoiceOpen {
+			return inv, illegalTransition(ev.Kind, status)
+		}
+		if !ev.Date.IsZero() {
+			inv.ClosingDate = ev.Date
+		}
+		inv.Status = domain.InvoiceClosed
+
+	case InvoiceEventPayment:
+		switch status {
+		case domain.InvoiceClosed, domain.InvoicePartiallyPaid, domain.InvoiceOverdue, domain.InvoiceInRotative:
+		default:
+			return inv, illegalTransition(ev.Kind, status)
+		}
+		if ev.Amount <= 0 {
+			return inv, ErrInvalidPayment
+		}
+		inv.PaidAmount += ev.Amount
+		switch {
+		case inv.PaidAmount >= inv.TotalAmount:
+			inv.Status = domain.InvoicePaid
+		case status == domain.InvoiceClosed:
+			inv.Status = domain.InvoicePartiallyPaid
+		}
+
+	case InvoiceEventDayPassed:
+		if status != domain.InvoiceClosed && status != domain.InvoicePartiallyPaid {
+			return inv, nil
+		}
+		if !ev.Date.After(inv.DueDate) {
+			return inv, nil
+		}
+		switch {
+		case inv.PaidAmount >= inv.TotalAmount:
+			// Nothing is due, e.g. a zero or credit invoice.
+			inv.Status = domain.InvoicePaid
+		case inv.PaidAmount > 0 && inv.PaidAmount >= inv.MinimumPayment:
+			inv.Status = domain.InvoiceInRotative
+		default:
+			inv.Status = domain.InvoiceOverdue
+		}
+
+	case InvoiceEventInstall:
+		switch status {
+		case domain.InvoiceClosed, domain.InvoicePartiallyPaid, domain.InvoiceOverdue, domain.InvoiceInRotative:
+			inv.Status = domain.InvoiceInstalled
+		default:
+			return inv, illegalTransition(ev.Kind, status)
+		}
+
+	case InvoiceEventWriteOff:
+		if status != domain.InvoiceOverdue && status != domain.InvoiceInRotative {
+			return inv, illegalTransition(ev.Kind, status)
+		}
+		inv.Status = domain.InvoiceWrittenOff
+
+	default:
+		return inv, fmt.Errorf("calc: unknown invoice event %q", ev.Kind)
+	}
+
+	return inv, nil
+}
+
+// InvoiceRotativeBalance returns the balance CalculateRotative applies to, and
+// whether it applies: only overdue and in-rotative invoices revolve, from the
+// due date on.
+func InvoiceRotativeBalance(inv domain.Invoice) (domain.RotativeBalance, bool) {
+	switch invoiceStatus(inv) {
+	case domain.InvoiceOverdue, domain.InvoiceInRotative:
+	default:
+		return domain.RotativeBalance{}, false
+	}
+
+	remaining := inv.TotalAmount - inv.PaidAmount
+	if remaining <= 0 {
+		return domain.RotativeBalance{}, false
+	}
+	return domain.RotativeBalance{Principal: remaining, StartDate: inv.DueDate}, true
+}
+
+func invoiceStatus(inv domain.Invoice) domain.InvoiceStatus {
+	if inv.Status == "" {
+		return domain.InvoiceOpen
+	}
+	return inv.Status
+}
+
+func illegalTransition(kind InvoiceEventKind, status domain.InvoiceStatus) error {
+	return fmt.Errorf("%w: %s on %s invoice", ErrIllegalTransition, kind, status)
+}
@@ -0,0 +1,166 @@
+package calc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func closedInvoice(t *testing.T) domain.Invoice {
+	t.Helper()
+	inv := domain.Invoice{
+		ID:             "inv-1",
+		DueDate:        utcDate(2024, 2, 10),
+		TotalAmount:    100_000,
+		MinimumPayment: 15_000,
+	}
+	inv, err := ApplyInvoiceEvent(inv, InvoiceEvent{Kind: InvoiceEventClose, Date: utcDate(2024, 1, 31)})
+	if err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return inv
+}
+
+func mustApply(t *testing.T, inv domain.Invoice, ev InvoiceEvent) domain.Invoice {
+	t.Helper()
+	inv, err := ApplyInvoiceEvent(inv, ev)
+	if err != nil {
+		t.Fatalf("%s: %v", ev.Kind, err)
+	}
+	return inv
+}
+
+func TestInvoiceLifecycle_PaidInFull(t *testing.T) {
+	inv := closedInvoice(t)
+	if inv.Status != domain.InvoiceClosed || !inv.ClosingDate.Equal(utcDate(2024, 1, 31)) {
+		t.Fatalf("expected closed on 2024-01-31, got %s %v", inv.Status, inv.ClosingDate)
+	}
+
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventPayment, Date: utcDate(2024, 2, 5), Amount: 40_000})
+	if inv.Status != domain.InvoicePartiallyPaid {
+		t.Fatalf("expected partially paid, got %s", inv.Status)
+	}
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventPayment, Date: utcDate(2024, 2, 10), Amount: 60_000})
+	if inv.Status != domain.InvoicePaid {
+		t.Fatalf("expected paid, got %s", inv.Status)
+	}
+
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventDayPassed, Date: utcDate(2024, 2, 11)})
+	if inv.Status != domain.InvoicePaid {
+		t.Fatalf("expected paid to be final, got %s", inv.Status)
+	}
+	if _, ok := InvoiceRotativeBalance(inv); ok {
+		t.Fatalf("rotative must not apply to a paid invoice")
+	}
+}
+
+func TestInvoiceLifecycle_MinimumPaidGoesToRotative(t *testing.T) {
+	inv := closedInvoice(t)
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventPayment, Date: utcDate(2024, 2, 9), Amount: 15_000})
+
+	// Due date itself is still on time.
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventDayPassed, Date: utcDate(2024, 2, 10)})
+	if inv.Status != domain.InvoicePartiallyPaid {
+		t.Fatalf("expected partially paid on due date, got %s", inv.Status)
+	}
+
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventDayPassed, Date: utcDate(2024, 2, 11)})
+	if inv.Status != domain.InvoiceInRotative {
+		t.Fatalf("expected in rotative, got %s", inv.Status)
+	}
+
+	balance, ok := InvoiceRotativeBalance(inv)
+	if !ok || balance.Principal != 85_000 || !balance.StartDate.Equal(inv.DueDate) {
+		t.Fatalf("expected rotative balance 85000 from due date, got %+v (%v)", balance, ok)
+	}
+}
+
+func TestInvoiceLifecycle_BelowMinimumIsOverdue(t *testing.T) {
+	inv := closedInvoice(t)
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventPayment, Date: utcDate(2024, 2, 9), Amount: 10_000})
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventDayPassed, Date: utcDate(2024, 2, 11)})
+	if inv.Status != domain.InvoiceOverdue {
+		t.Fatalf("expected overdue, got %s", inv.Status)
+	}
+
+	unpaid := mustApply(t, closedInvoice(t), InvoiceEvent{Kind: InvoiceEventDayPassed, Date: utcDate(2024, 2, 11)})
+	if unpaid.Status != domain.InvoiceOverdue {
+		t.Fatalf("expected unpaid invoice overdue, got %s", unpaid.Status)
+	}
+
+	inv = mustApply(t, inv, InvoiceEvent{Kind: InvoiceEventWriteOff, Date: utcDate(2024, 8, 1)})
+	if inv.Status != domain.InvoiceWrittenOff {
+		t.Fatalf("expected written off, got %s", inv.Status)
+	}
+}
+
+func TestInvoiceLifecycle_DayPassed(t *testing.T) {
+	closed := closedInvoice(t)
+	partial := mustApply(t, closed, InvoiceEvent{Kind: InvoiceEventPayment, Amount: 15_000})
+	zero := closed
+	zero.TotalAmount, zero.MinimumPayment = 0, 0
+	credit := zero
+	credit.TotalAmount = -5_000
+
+	cases := []struct {
+		name string
+		inv  domain.Invoice
+		date time.Time
+		want domain.InvoiceStatus
+	}{
+		{"closed on due date", closed, utcDate(2024, 2, 10), domain.InvoiceClosed},
+		{"closed unpaid", closed, utcDate(2024, 2, 11), domain.InvoiceOverdue},
+		{"minimum paid", partial, utcDate(2024, 2, 11), domain.InvoiceInRotative},
+		{"zero total", zero, utcDate(2024, 2, 11), domain.InvoicePaid},
+		{"credit balance", credit, utcDate(2024, 2, 11), domain.InvoicePaid},
+	}
+	for _, tc := range cases {
+		got := mustApply(t, tc.inv, InvoiceEvent{Kind: InvoiceEventDayPassed, Date: tc.date})
+		if got.Status != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, got.Status)
+		}
+	}
+}
+
+func TestInvoiceLifecycle_Install(t *testing.T) {
+	inv := mustApply(t, closedInvoice(t), InvoiceEvent{Kind: InvoiceEventInstall, Date: utcDate(2024, 2, 5)})
+	if inv.Status != domain.InvoiceInstalled {
+		t.Fatalf("expected installed, got %s", inv.Status)
+	}
+	if _, err := ApplyInvoiceEvent(inv, InvoiceEvent{Kind: InvoiceEventPayment, Amount: 1_000}); !errors.Is(err, ErrIllegalTransition) {
+		t.Fatalf("expected ErrIllegalTransition paying an installed invoice, got %v", err)
+	}
+}
+
+func TestInvoiceLifecycle_IllegalTransitions(t *testing.T) {
+	open := domain.Invoice{TotalAmount: 100_000}
+	closed := closedInvoice(t)
+	paid := mustApply(t, closed, InvoiceEvent{Kind: InvoiceEventPayment, Amount: 100_000})
+
+	cases := []struct {
+		name string
+		inv  domain.Invoice
+		ev   InvoiceEvent
+	}{
+		{"pay open", open, InvoiceEvent{Kind: InvoiceEventPayment, Amount: 1_000}},
+		{"close twice", closed, InvoiceEvent{Kind: InvoiceEventClose}},
+		{"write off before due", closed, InvoiceEvent{Kind: InvoiceEventWriteOff}},
+		{"install paid", paid, InvoiceEvent{Kind: InvoiceEventInstall}},
+		{"pay paid", paid, InvoiceEvent{Kind: InvoiceEventPayment, Amount: 1}},
+	}
+	for _, tc := range cases {
+		got, err := ApplyInvoiceEvent(tc.inv, tc.ev)
+		if !errors.Is(err, ErrIllegalTransition) {
+			t.Fatalf("%s: expected ErrIllegalTransition, got %v", tc.name, err)
+		}
+		if got.Status != tc.inv.Status {
+			t.Fatalf("%s: status changed on error", tc.name)
+		}
+	}
+
+	if _, err := ApplyInvoiceEvent(closed, InvoiceEvent{Kind: InvoiceEventPayment}); !errors.Is(err, ErrInvalidPayment) {
+		t.Fatalf("expected ErrInvalidPayment, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package calc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// InstallmentInvoiceLines returns the lines a plan adds to the invoice due on
+// dueDate (compared by calendar date). Parcelado emissor shows the principal,
+// interest and IOF as separate lines; parcelado lojista shows a single line,
+// since the cardholder owes only the parcel.
+func InstallmentInvoiceLines(description string, plan domain.InstallmentPlan, dueDate time.Time) []domain.InvoiceLine {
+	var lines []domain.InvoiceLine
+	n := len(plan.Installments)
+	for _, inst := range plan.Installments {
+		if !sameDate(inst.DueDate, dueDate) {
+			continue
+		}
+		label := fmt.Sprintf("%s %02d/%02d", description, inst.Number, n)
+
+		if inst.Funding == domain.FundingMerchant {
+			lines = append(lines, domain.InvoiceLine{
+				Kind:        domain.InvoiceLineInstallment,
+				Description: label + " (parcelado lojista)",
+				Amount:      inst.Amount,
+			})
+			continue
+		}
+
+		lines = append(lines, domain.InvoiceLine{
+			Kind:        domain.InvoiceLineInstallment,
+			Description: label,
+			Amount:      inst.Principal,
+		})
+		if inst.Interest > 0 {
+			lines = append(lines, domain.InvoiceLine{
+				Kind:        domain.InvoiceLineInstallmentInterest,
+				Description: "Juros parcelamento " + label,
+				Amount:      inst.Interest,
+			})
+		}
+		if inst.IOF > 0 {
+			lines = append(lines, domain.InvoiceLine{
+				Kind:        domain.InvoiceLineIOF,
+				Description: "IOF parcelamento " + label,
+				Amount:      inst.IOF,
+			})
+		}
+	}
+	return lines
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// RotativeInvoiceLines returns the lines of the rotative charges of result:
+// interest, late interest and late fee as charges, and IOF. Zero amounts are
+// omitted.
+func RotativeInvoiceLines(result RotativeResult) []domain.InvoiceLine {
+	candidates := []domain.InvoiceLine{
+		{Kind: domain.InvoiceLineCharge, Description: "Juros rotativo", Amount: result.Interest},
+		{Kind: domain.InvoiceLineCharge, Description: "Juros de mora", Amount: result.LateInterest},
+		{Kind: domain.InvoiceLineCharge, Description: "Multa por atraso", Amount: result.LateFee},
+		{Kind: domain.InvoiceLineIOF, Description: "IOF rotativo", Amount: result.IOF},
+	}
+
+	var lines []domain.InvoiceLine
+	for _, line := range candidates {
+		if line.Amount > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,46 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestInstallmentInvoiceLines_Issuer(t *testing.T) {
+	plan := CalculateInstallmentPlan(100_000, 12, utcDate(2024, 1, 5), utcDate(2024, 2, 10),
+		defaultIOFConfig(), config.InstallmentConfig{MonthlyRate: 19_900})
+
+	lines := InstallmentInvoiceLines("LOJA X", plan, utcDate(2024, 4, 10))
+	if len(lines) != 3 {
+		t.Fatalf("expected principal, interest and IOF lines, got %+v", lines)
+	}
+
+	inst := plan.Installments[2]
+	if lines[0].Description != "LOJA X 03/12" || lines[0].Amount != inst.Principal {
+		t.Fatalf("unexpected principal line %+v", lines[0])
+	}
+	if lines[1].Kind != domain.InvoiceLineInstallmentInterest || lines[1].Amount != inst.Interest {
+		t.Fatalf("unexpected interest line %+v", lines[1])
+	}
+	if lines[2].Kind != domain.InvoiceLineIOF || lines[2].Amount != inst.IOF {
+		t.Fatalf("unexpected IOF line %+v", lines[2])
+	}
+}
+
+func TestInstallmentInvoiceLines_Merchant(t *testing.T) {
+	plan := CalculateInstallmentPlan(100_000, 10, utcDate(2024, 1, 5), utcDate(2024, 2, 10),
+		defaultIOFConfig(), config.InstallmentConfig{Funding: domain.FundingMerchant})
+
+	lines := InstallmentInvoiceLines("LOJA X", plan, utcDate(2024, 2, 10))
+	if len(lines) != 1 {
+		t.Fatalf("expected a single line, got %+v", lines)
+	}
+	if lines[0].Description != "LOJA X 01/10 (parcelado lojista)" || lines[0].Amount != 10_000 {
+		t.Fatalf("unexpected line %+v", lines[0])
+	}
+
+	if got := InstallmentInvoiceLines("LOJA X", plan, utcDate(2025, 2, 10)); len(got) != 0 {
+		t.Fatalf("expected no lines outside the plan, got %+v", got)
+	}
+}
@@ -10,14 +10,44 @@ import (
 //
 // Input validation (non-negative principal, valid days) is the caller's responsibility.
 func CalculateIOF(principal domain.Money, days int, cfg config.IOFConfig) domain.Money {
-	daily := mulRateDays(principal, cfg.DailyRate, days)
-	additional := mulRate(principal, cfg.AdditionalRate)
-	total := daily + additional
+	iof, _ := creditIOF(principal, days, cfg)
+	return iof
+}
+
+// creditIOF is CalculateIOF with its rounding residue.
+func creditIOF(principal domain.Money, days int, cfg config.IOFConfig) (domain.Money, int64) {
+	daily, dailyResidue := roundProduct(domain.RateDenominator, cfg.Rounding, int64(principal), int64(cfg.DailyRate), int64(days))
+	additional, additionalResidue := roundProduct(domain.RateDenominator, cfg.Rounding, int64(principal), int64(cfg.AdditionalRate))
 
-	maxVal := mulRate(principal, cfg.MaxAnnualRate)
-	if total > maxVal {
-		total = maxVal
+	maxVal, maxResidue := roundProduct(domain.RateDenominator, cfg.Rounding, int64(principal), int64(cfg.MaxAnnualRate))
+	if daily+additional > maxVal {
+		return maxVal, maxResidue
 	}
 
-	return total
+	return daily + additional, dailyResidue + additionalResidue
+}
+
+// IOFBreakdown splits the credit IOF into its daily and additional parts, for
+// collection reporting. Total always equals CalculateIOF.
+type IOFBreakdown struct {
+	Daily      domain.Money
+	Additional domain.Money
+	Total      domain.Money
+	Capped     bool
+}
+
+// CalculateIOFBreakdown is CalculateIOF with the daily and additional parts.
+// When the annual cap applies, the daily part absorbs the reduction.
+func CalculateIOFBreakdown(principal domain.Money, days int, cfg config.IOFConfig) IOFBreakdown {
+	b := IOFBreakdown{
+		Daily:      mulRateDays(principal, cfg.DailyRate, days, cfg.Rounding),
+		Additional: mulRate(principal, cfg.AdditionalRate, cfg.Rounding),
+	}
+	b.Total = CalculateIOF(principal, days, cfg)
+	if b.Daily+b.Additional > b.Total {
+		b.Capped = true
+		b.Additional = min(b.Additional, b.Total)
+		b.Daily = b.Total - b.Additional
+	}
+	return b
 }
@@ -36,3 +36,19 @@ func TestCalculateIOF(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateIOFBreakdown_MatchesTotal(t *testing.T) {
+	cfg := defaultIOFConfig()
+	for _, days := range []int{0, 1, 30, 365, 1000} {
+		b := CalculateIOFBreakdown(123_457, days, cfg)
+		if b.Total != CalculateIOF(123_457, days, cfg) || b.Daily+b.Additional != b.Total {
+			t.Fatalf("%d days: breakdown %+v does not add up", days, b)
+		}
+	}
+
+	// 1000 days: daily 0.0082% × 1000 = 8.2% exceeds the 4.08% cap.
+	b := CalculateIOFBreakdown(100_000, 1000, cfg)
+	if !b.Capped || b.Additional != 380 || b.Daily != 4_080-380 {
+		t.Fatalf("expected capped daily part, got %+v", b)
+	}
+}
@@ -9,8 +9,14 @@ import (
 //
 // Input validation (non-negative principal) is the caller's responsibility.
 func CalculateLateFee(principal domain.Money, cfg config.LateFeeConfig) domain.Money {
+	lateFee, _ := lateFee(principal, cfg)
+	return lateFee
+}
+
+// lateFee is CalculateLateFee with its rounding residue.
+func lateFee(principal domain.Money, cfg config.LateFeeConfig) (domain.Money, int64) {
 	if cfg.Rate <= 0 {
-		return 0
+		return 0, 0
 	}
-	return mulRate(principal, cfg.Rate)
+	return roundProduct(domain.RateDenominator, cfg.Rounding, int64(principal), int64(cfg.Rate))
 }
@@ -10,10 +10,14 @@ import (
 //
 // Input validation (non-negative principal, valid days) is the caller's responsibility.
 func CalculateLateInterest(principal domain.Money, days int, cfg config.LateInterestConfig) domain.Money {
+	lateInterest, _ := lateInterest(principal, days, cfg)
+	return lateInterest
+}
+
+// lateInterest is CalculateLateInterest with its rounding residue.
+func lateInterest(principal domain.Money, days int, cfg config.LateInterestConfig) (domain.Money, int64) {
 	if cfg.MonthlyRate <= 0 || days <= 0 {
-		return 0
+		return 0, 0
 	}
-	denom := int64(30) * domain.RateDenominator
-	num := int64(principal) * int64(cfg.MonthlyRate) * int64(days)
-	return domain.Money((num + denom/2) / denom)
+	return roundProduct(30*domain.RateDenominator, cfg.Rounding, int64(principal), int64(cfg.MonthlyRate), int64(days))
 }
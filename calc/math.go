@@ -1,21 +1,110 @@
 package calc
 
 import (
+	"errors"
+	"math"
+	"math/bits"
 	"time"
 
 	"github.com/thiagozs/go-calc-charges-engine/domain"
 )
 
-// mulRate computes (principal * rate) rounded to the nearest centavo.
-// Uses round-half-up: (principal * rate + RateDenominator/2) / RateDenominator.
-func mulRate(principal domain.Money, rate domain.Rate) domain.Money {
-	return domain.Money((int64(principal)*int64(rate) + domain.RateDenominator/2) / domain.RateDenominator)
+// mulRate computes (principal * rate) rounded to the centavo with mode.
+func mulRate(principal domain.Money, rate domain.Rate, mode domain.RoundingMode) domain.Money {
+	q, _ := roundProduct(domain.RateDenominator, mode, int64(principal), int64(rate))
+	return q
 }
 
-// mulRateDays computes (principal * rate * days) rounded to the nearest centavo.
+// mulRateDays computes (principal * rate * days) rounded to the centavo with mode.
 // The full product is accumulated before dividing to avoid premature truncation.
-func mulRateDays(principal domain.Money, rate domain.Rate, days int) domain.Money {
-	return domain.Money((int64(principal)*int64(rate)*int64(days) + domain.RateDenominator/2) / domain.RateDenominator)
+func mulRateDays(principal domain.Money, rate domain.Rate, days int, mode domain.RoundingMode) domain.Money {
+	q, _ := roundProduct(domain.RateDenominator, mode, int64(principal), int64(rate), int64(days))
+	return q
+}
+
+// MaxAmount is the largest amount, in centavos (R$ 10 trilhoes), the engine
+// accepts as input: principals, purchase amounts, payments and invoice
+// totals. Within it, with rates up to 100% and periods up to 10 years, no
+// calculation overflows int64. Larger inputs are outside the contract and
+// may panic with errAmountOverflow; callers validate against it.
+const MaxAmount = 1_000_000_000_000_000
+
+var errAmountOverflow = errors.New("calc: amount overflows int64")
+
+// roundProduct returns the product of factors divided by den (den > 0),
+// rounded to the centavo with mode, and the rounding residue: the rounded
+// minus the exact quotient, in 1/ResidueDenominator of a centavo. Negative
+// quotients round symmetrically.
+//
+// The product is computed in 128 bits. Amounts up to R$ 10 trilhoes
+// (MaxAmount) with rates up to 100% and periods up to 10 years stay below
+// int64; a quotient (or product) that does not fit cannot be an amount and
+// panics with errAmountOverflow instead of returning a wrong value.
+func roundProduct(den int64, mode domain.RoundingMode, factors ...int64) (domain.Money, int64) {
+	neg := false
+	var hi, lo uint64 = 0, 1
+	for _, f := range factors {
+		if f < 0 {
+			neg = !neg
+		}
+		u := uint64(f)
+		if f < 0 {
+			u = -u
+		}
+		h1, l := bits.Mul64(lo, u)
+		h2, h := bits.Mul64(hi, u)
+		h, carry := bits.Add64(h, h1, 0)
+		if h2 != 0 || carry != 0 {
+			panic(errAmountOverflow)
+		}
+		hi, lo = h, l
+	}
+
+	d := uint64(den)
+	if hi >= d {
+		panic(errAmountOverflow)
+	}
+	q, rem := bits.Div64(hi, lo, d)
+
+	var away bool
+	if rem != 0 {
+		switch mode {
+		case domain.RoundDown:
+			away = false
+		case domain.RoundHalfEven:
+			away = 2*rem > d || (2*rem == d && q%2 != 0)
+		default:
+			// RoundHalfUp. Configs loaded from the environment or JSON
+			// never hold other modes (see domain.RoundingMode.UnmarshalText).
+			away = 2*rem >= d
+		}
+	}
+	if q > math.MaxInt64 || (away && q == math.MaxInt64) {
+		panic(errAmountOverflow)
+	}
+	if away {
+		q++
+	}
+
+	// |rounded| − |exact|, in 1/den of a centavo.
+	diff := -int64(rem)
+	if away {
+		diff += den
+	}
+	residue := divNearest(diff*domain.ResidueDenominator, den)
+	if neg {
+		return -domain.Money(q), -residue
+	}
+	return domain.Money(q), residue
+}
+
+// divNearest returns a/b (b > 0) rounded to the nearest integer, ties away
+// from zero.
+func divNearest(a, b int64) int64 {
+	if a < 0 {
+		return -((-a + b/2) / b)
+	}
+	return (a + b/2) / b
 }
 
 // addMonths adds the given number of months to a time.
@@ -8,14 +8,14 @@ import (
 )
 
 func TestMulRate_ZeroPrincipal(t *testing.T) {
-	got := mulRate(0, 120_000)
+	got := mulRate(0, 120_000, domain.RoundHalfUp)
 	if got != 0 {
 		t.Fatalf("expected 0, got %d", got)
 	}
 }
 
 func TestMulRate_ZeroRate(t *testing.T) {
-	got := mulRate(100_000, 0)
+	got := mulRate(100_000, 0, domain.RoundHalfUp)
 	if got != 0 {
 		t.Fatalf("expected 0, got %d", got)
 	}
@@ -23,7 +23,7 @@ func TestMulRate_ZeroRate(t *testing.T) {
 
 func TestMulRate_ExactDivision(t *testing.T) {
 	// 100_000 * 20_000 / 1_000_000 = 2_000
-	got := mulRate(100_000, 20_000)
+	got := mulRate(100_000, 20_000, domain.RoundHalfUp)
 	if got != 2_000 {
 		t.Fatalf("expected 2000, got %d", got)
 	}
@@ -31,7 +31,7 @@ func TestMulRate_ExactDivision(t *testing.T) {
 
 func TestMulRate_RoundsUp(t *testing.T) {
 	// 12_345 * 35_000 = 432_075_000 + 500_000 = 432_575_000 / 1_000_000 = 432
-	got := mulRate(12_345, 35_000)
+	got := mulRate(12_345, 35_000, domain.RoundHalfUp)
 	if got != 432 {
 		t.Fatalf("expected 432, got %d", got)
 	}
@@ -39,14 +39,14 @@ func TestMulRate_RoundsUp(t *testing.T) {
 
 func TestMulRate_LargeValue(t *testing.T) {
 	// R$1 billion = 100_000_000_000 centavos, rate = 1.0 (1_000_000)
-	got := mulRate(100_000_000_000, 1_000_000)
+	got := mulRate(100_000_000_000, 1_000_000, domain.RoundHalfUp)
 	if got != 100_000_000_000 {
 		t.Fatalf("expected 100_000_000_000, got %d", got)
 	}
 }
 
 func TestMulRateDays_ZeroDays(t *testing.T) {
-	got := mulRateDays(100_000, 82, 0)
+	got := mulRateDays(100_000, 82, 0, domain.RoundHalfUp)
 	if got != 0 {
 		t.Fatalf("expected 0, got %d", got)
 	}
@@ -54,7 +54,7 @@ func TestMulRateDays_ZeroDays(t *testing.T) {
 
 func TestMulRateDays_IOFDaily(t *testing.T) {
 	// 100_000 * 82 * 30 = 246_000_000 + 500_000 = 246_500_000 / 1_000_000 = 246
-	got := mulRateDays(100_000, 82, 30)
+	got := mulRateDays(100_000, 82, 30, domain.RoundHalfUp)
 	if got != 246 {
 		t.Fatalf("expected 246, got %d", got)
 	}
@@ -87,24 +87,32 @@ func TestAddMonths(t *testing.T) {
 	}
 }
 
-func TestFixedPow(t *testing.T) {
-	// (1 + 0.0199)^1 = 1.0199 => fixedPow(19900, 1) should be ~1_019_900
-	got := fixedPow(19_900, 1)
-	if got != 1_019_900 {
-		t.Fatalf("expected 1019900, got %d", got)
+func TestRoundProduct_Modes(t *testing.T) {
+	cases := []struct {
+		num, den int64
+		mode     domain.RoundingMode
+		want     domain.Money
+		residue  int64
+	}{
+		{2_500_000, 1_000_000, domain.RoundHalfUp, 3, 500_000},
+		{2_500_000, 1_000_000, "", 3, 500_000},
+		{2_500_000, 1_000_000, domain.RoundHalfEven, 2, -500_000},
+		{3_500_000, 1_000_000, domain.RoundHalfEven, 4, 500_000},
+		{2_500_000, 1_000_000, domain.RoundDown, 2, -500_000},
+		{2_600_000, 1_000_000, domain.RoundHalfEven, 3, 400_000},
+		{2_600_000, 1_000_000, domain.RoundDown, 2, -600_000},
+		{2_400_001, 1_000_000, domain.RoundHalfUp, 2, -400_001},
+		{-2_500_000, 1_000_000, domain.RoundHalfUp, -3, -500_000},
+		{-2_500_000, 1_000_000, domain.RoundHalfEven, -2, 500_000},
+		{-2_600_000, 1_000_000, domain.RoundDown, -2, 600_000},
+		{4_000_000, 1_000_000, domain.RoundDown, 4, 0},
+		// 45_000_001 / 30e6 = 1.50000003...: residue rounded to the millionth.
+		{45_000_001, 30_000_000, domain.RoundHalfUp, 2, 500_000},
 	}
-
-	// (1 + 0.0199)^2 ≈ 1.040196 => ~1_040_196
-	got2 := fixedPow(19_900, 2)
-	// Allow small rounding: should be 1_040_196 or 1_040_197
-	if got2 < 1_040_195 || got2 > 1_040_197 {
-		t.Fatalf("expected ~1040196, got %d", got2)
-	}
-}
-
-func TestFixedPow_Zero(t *testing.T) {
-	got := fixedPow(120_000, 0)
-	if got != domain.RateDenominator {
-		t.Fatalf("expected %d, got %d", domain.RateDenominator, got)
+	for _, tc := range cases {
+		got, residue := roundProduct(tc.den, tc.mode, tc.num)
+		if got != tc.want || residue != tc.residue {
+			t.Fatalf("roundProduct(%d, %q, %d) = %d, %d; want %d, %d", tc.den, tc.mode, tc.num, got, residue, tc.want, tc.residue)
+		}
 	}
 }
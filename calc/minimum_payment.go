@@ -0,0 +1,69 @@
+package calc
+
+import (
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// MinimumPayment is the breakdown of an invoice minimum payment.
+type MinimumPayment struct {
+	Outstanding  domain.Money // TotalAmount - PaidAmount
+	Revolving    domain.Money // balance subject to the percentage
+	Percentage   domain.Money // Rate × Revolving
+	Installments domain.Money // installment parcels billed in full
+	Charges      domain.Money // charges and IOF billed in full
+	Floored      bool         // the absolute floor was applied
+	Amount       domain.Money
+}
+
+// CalculateMinimumPayment computes the minimum payment of inv.
+//
+// Installment lines (principal and interest) and charge lines (rotative
+// charges and IOF) are billed in full when included by cfg; everything else
+// is revolving and contributes cfg.Rate of its amount. The sum is raised to
+// cfg.Floor and finally limited to the outstanding balance, so an invoice
+// smaller than the floor is paid in full.
+func CalculateMinimumPayment(inv domain.Invoice, cfg config.MinimumPaymentConfig) MinimumPayment {
+	outstanding := inv.TotalAmount - inv.PaidAmount
+	if outstanding <= 0 {
+		return MinimumPayment{}
+	}
+
+	var installments, charges domain.Money
+	for _, line := range inv.Lines {
+		switch line.Kind {
+		case domain.InvoiceLineInstallment, domain.InvoiceLineInstallmentInterest:
+			if cfg.IncludeInstallments {
+				installments += line.Amount
+			}
+		case domain.InvoiceLineCharge, domain.InvoiceLineIOF:
+			if cfg.IncludeCharges {
+				charges += line.Amount
+			}
+		}
+	}
+
+	revolving := max(outstanding-installments-charges, 0)
+	percentage := mulRate(revolving, cfg.Rate, cfg.Rounding)
+
+	m := MinimumPayment{
+		Outstanding:  outstanding,
+		Revolving:    revolving,
+		Percentage:   percentage,
+		Installments: installments,
+		Charges:      charges,
+		Amount:       percentage + installments + charges,
+	}
+	if m.Amount < cfg.Floor {
+		m.Amount = cfg.Floor
+		m.Floored = true
+	}
+	m.Amount = min(m.Amount, outstanding)
+	return m
+}
+
+// WithMinimumPayment returns inv with MinimumPayment set by CalculateMinimumPayment.
+func WithMinimumPayment(inv domain.Invoice, cfg config.MinimumPaymentConfig) domain.Invoice {
+	inv.MinimumPayment = CalculateMinimumPayment(inv, cfg).Amount
+	return inv
+}
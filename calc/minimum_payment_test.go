@@ -0,0 +1,92 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestMinimumPayment_PercentagePlusParcelsAndCharges(t *testing.T) {
+	inv := domain.Invoice{
+		TotalAmount: 200_000,
+		Lines: []domain.InvoiceLine{
+			{Kind: domain.InvoiceLinePurchase, Amount: 150_000},
+			{Kind: domain.InvoiceLineInstallment, Amount: 30_000},
+			{Kind: domain.InvoiceLineInstallmentInterest, Amount: 2_000},
+			{Kind: domain.InvoiceLineCharge, Amount: 15_000},
+			{Kind: domain.InvoiceLineIOF, Amount: 3_000},
+		},
+	}
+
+	m := CalculateMinimumPayment(inv, defaultMinimumPaymentConfig())
+
+	// 15% × 1500.00 + 320.00 + 180.00
+	if m.Revolving != 150_000 || m.Percentage != 22_500 || m.Installments != 32_000 || m.Charges != 18_000 {
+		t.Fatalf("unexpected breakdown: %+v", m)
+	}
+	if m.Amount != 72_500 || m.Floored {
+		t.Fatalf("expected 72500 without floor, got %d (floored=%v)", m.Amount, m.Floored)
+	}
+}
+
+func TestMinimumPayment_ExcludedPartsAreRevolving(t *testing.T) {
+	inv := domain.Invoice{
+		TotalAmount: 100_000,
+		Lines: []domain.InvoiceLine{
+			{Kind: domain.InvoiceLinePurchase, Amount: 80_000},
+			{Kind: domain.InvoiceLineInstallment, Amount: 20_000},
+		},
+	}
+	cfg := defaultMinimumPaymentConfig()
+	cfg.IncludeInstallments = false
+
+	m := CalculateMinimumPayment(inv, cfg)
+
+	if m.Revolving != 100_000 || m.Amount != 15_000 {
+		t.Fatalf("expected 15%% of 100000, got %+v", m)
+	}
+}
+
+func TestMinimumPayment_FloorAndOutstandingCap(t *testing.T) {
+	cfg := defaultMinimumPaymentConfig()
+
+	m := CalculateMinimumPayment(domain.Invoice{TotalAmount: 20_000}, cfg)
+	if m.Amount != 5_000 || !m.Floored {
+		t.Fatalf("expected floor 5000, got %+v", m)
+	}
+
+	// Smaller than the floor: pay in full.
+	m = CalculateMinimumPayment(domain.Invoice{TotalAmount: 3_000}, cfg)
+	if m.Amount != 3_000 {
+		t.Fatalf("expected full balance 3000, got %d", m.Amount)
+	}
+
+	m = CalculateMinimumPayment(domain.Invoice{TotalAmount: 20_000, PaidAmount: 20_000}, cfg)
+	if m.Amount != 0 {
+		t.Fatalf("expected no minimum on a paid invoice, got %d", m.Amount)
+	}
+}
+
+func TestWithMinimumPayment_SetsInvoiceField(t *testing.T) {
+	inv := WithMinimumPayment(domain.Invoice{TotalAmount: 100_000, PaidAmount: 40_000}, defaultMinimumPaymentConfig())
+
+	// 15% × 600.00 = 90.00
+	if inv.MinimumPayment != 9_000 {
+		t.Fatalf("expected 9000, got %d", inv.MinimumPayment)
+	}
+}
+
+func TestRotativeInvoiceLines_FeedMinimumPaymentCharges(t *testing.T) {
+	lines := RotativeInvoiceLines(RotativeResult{Interest: 1_000, LateFee: 200, IOF: 50})
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines without zero late interest, got %d", len(lines))
+	}
+
+	inv := domain.Invoice{TotalAmount: 11_250, Lines: lines}
+	m := CalculateMinimumPayment(inv, config.MinimumPaymentConfig{Rate: 150_000, IncludeCharges: true})
+	// 15% × 100.00 + 12.50
+	if m.Charges != 1_250 || m.Amount != 2_750 {
+		t.Fatalf("expected charges 1250 and minimum 2750, got %+v", m)
+	}
+}
@@ -0,0 +1,131 @@
+package calc
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// PayoffInput is the account state a payoff quote is computed from.
+type PayoffInput struct {
+	// Billed is the unpaid amount already billed and not revolving: the open
+	// cycle and closed invoices not yet due (installment parcels posted to
+	// them included).
+	Billed   domain.Money
+	Rotative []domain.RotativeBalance
+	Plans    []PayoffPlan
+}
+
+// PayoffPlan is an installment plan with Posted parcels already billed.
+// MonthlyRate is the contract rate used to discount the remaining parcels.
+type PayoffPlan struct {
+	Plan        domain.InstallmentPlan
+	MonthlyRate domain.Rate
+	Posted      int
+}
+
+// PayoffQuote is the amount that settles an account on Date. The caller
+// (ledger) should persist it; it is honored until ValidUntil.
+type PayoffQuote struct {
+	Date             time.Time
+	ValidUntil       time.Time
+	Billed           domain.Money
+	Rotative         []RotativeResult
+	RotativeTotal    domain.Money
+	Installments     []InstallmentPayoff
+	InstallmentTotal domain.Money
+	Discount         domain.Money // early-settlement discount on the remaining parcels
+	Total            domain.Money
+}
+
+// InstallmentPayoff is the early settlement of one future parcel.
+type InstallmentPayoff struct {
+	Number    int
+	DueDate   time.Time
+	Scheduled domain.Money // principal + interest as scheduled
+	Discount  domain.Money
+	IOF       domain.Money
+	Amount    domain.Money // Scheduled - Discount + IOF
+}
+
+// CalculatePayoff quotes the payoff of in on at.
+//
+// Rotative balances accrue charges up to at (CalculateRotative). Each
+// remaining parcel is brought to present value at its contract rate for the
+// days until its due date (whole months compounded, remaining days pro rata),
+// so future interest is not charged (antecipacao com reducao proporcional dos
+// juros). Parcel IOF, charged when the plan was contracted, is not discounted.
+// Merchant-funded and interest-free parcels carry no interest to discount.
+//
+// The quote is valid until at + payoffCfg.ValidityDays.
+func CalculatePayoff(
+	in PayoffInput,
+	at time.Time,
+	iofCfg config.IOFConfig,
+	intCfg config.InterestConfig,
+	lateFeeCfg config.LateFeeConfig,
+	lateInterestCfg config.LateInterestConfig,
+	rulesCfg config.RotativeRulesConfig,
+	payoffCfg config.PayoffConfig,
+) PayoffQuote {
+	q := PayoffQuote{
+		Date:       at,
+		ValidUntil: at.AddDate(0, 0, payoffCfg.ValidityDays),
+		Billed:     in.Billed,
+	}
+
+	for _, balance := range in.Rotative {
+		result := CalculateRotative(balance, at, iofCfg, intCfg, lateFeeCfg, lateInterestCfg, rulesCfg)
+		q.Rotative = append(q.Rotative, result)
+		q.RotativeTotal += result.Total
+	}
+
+	for _, p := range in.Plans {
+		rate := p.MonthlyRate
+		if p.Plan.Funding == domain.FundingMerchant {
+			rate = 0
+		}
+		for _, inst := range p.Plan.Installments[min(p.Posted, len(p.Plan.Installments)):] {
+			scheduled := inst.Principal + inst.Interest
+			present := presentValueAt(scheduled, rate, daysBetween(at, inst.DueDate), payoffCfg.Rounding)
+			// Never below the principal still owed.
+			present = max(present, inst.Principal)
+
+			ip := InstallmentPayoff{
+				Number:    inst.Number,
+				DueDate:   inst.DueDate,
+				Scheduled: scheduled,
+				Discount:  scheduled - present,
+				IOF:       inst.IOF,
+				Amount:    present + inst.IOF,
+			}
+			q.Installments = append(q.Installments, ip)
+			q.InstallmentTotal += ip.Amount
+			q.Discount += ip.Discount
+		}
+	}
+
+	q.Total = q.Billed + q.RotativeTotal + q.InstallmentTotal
+	return q
+}
+
+// presentValueAt discounts amount due in days at the monthly rate:
+// amount / ((1+r)^months × (1 + r × rest/30)), rounded with mode.
+func presentValueAt(amount domain.Money, rate domain.Rate, days int, mode domain.RoundingMode) domain.Money {
+	if rate == 0 || days <= 0 {
+		return amount
+	}
+
+	months, rest := days/30, int64(days%30)
+	bm, dm := ratePowers(rate, months)
+	month := new(big.Int).Mul(big.NewInt(30), rateDenominator)
+
+	num := new(big.Int).Mul(big.NewInt(int64(amount)), dm)
+	num.Mul(num, month)
+	den := new(big.Int).Add(month, big.NewInt(int64(rate)*rest))
+	den.Mul(den, bm)
+
+	return domain.Money(quoRound(num, den, mode).Int64())
+}
@@ -0,0 +1,83 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestPresentValueAt(t *testing.T) {
+	cases := []struct {
+		days int
+		want domain.Money
+	}{
+		{0, 10_000},
+		{30, 9_804}, // 10000 / 1.02
+		{45, 9_707}, // 10000 / (1.02 × 1.01)
+		{60, 9_612}, // 10000 / 1.02^2
+	}
+	for _, tc := range cases {
+		if got := presentValueAt(10_000, 20_000, tc.days, domain.RoundHalfUp); got != tc.want {
+			t.Fatalf("%d days: expected %d, got %d", tc.days, tc.want, got)
+		}
+	}
+}
+
+func TestPayoff_CombinesBilledRotativeAndInstallments(t *testing.T) {
+	iofCfg := defaultIOFConfig()
+	plan := CalculateInstallmentPlan(60_000, 3, utcDate(2024, 1, 10), utcDate(2024, 2, 10),
+		iofCfg, config.InstallmentConfig{MonthlyRate: 19_900})
+	at := utcDate(2024, 2, 15)
+	in := PayoffInput{
+		Billed:   1_000,
+		Rotative: []domain.RotativeBalance{{Principal: 50_000, StartDate: utcDate(2024, 2, 10)}},
+		Plans:    []PayoffPlan{{Plan: plan, MonthlyRate: 19_900, Posted: 1}},
+	}
+
+	q := CalculatePayoff(in, at, iofCfg, defaultInterestConfig(), defaultLateFeeConfig(),
+		defaultLateInterestConfig(), defaultRotativeRulesConfig(), config.PayoffConfig{ValidityDays: 1})
+
+	rotative := CalculateRotative(in.Rotative[0], at, iofCfg, defaultInterestConfig(), defaultLateFeeConfig(),
+		defaultLateInterestConfig(), defaultRotativeRulesConfig())
+	if q.RotativeTotal != rotative.Total {
+		t.Fatalf("expected rotative accrued to quote date %d, got %d", rotative.Total, q.RotativeTotal)
+	}
+
+	if len(q.Installments) != 2 || q.Installments[0].Number != 2 {
+		t.Fatalf("expected parcels 2 and 3, got %+v", q.Installments)
+	}
+	var remaining domain.Money
+	for _, ip := range q.Installments {
+		inst := plan.Installments[ip.Number-1]
+		remaining += inst.Principal
+		if ip.Discount <= 0 || ip.Amount < inst.Principal+inst.IOF || ip.Amount != ip.Scheduled-ip.Discount+ip.IOF {
+			t.Fatalf("parcel %d: unexpected payoff %+v", ip.Number, ip)
+		}
+	}
+	if q.InstallmentTotal-plan.Installments[1].IOF-plan.Installments[2].IOF < remaining {
+		t.Fatalf("payoff must cover the remaining principal %d", remaining)
+	}
+
+	if q.Total != 1_000+q.RotativeTotal+q.InstallmentTotal {
+		t.Fatalf("total %d does not add up", q.Total)
+	}
+	if !q.ValidUntil.Equal(utcDate(2024, 2, 16)) {
+		t.Fatalf("expected validity 2024-02-16, got %v", q.ValidUntil)
+	}
+}
+
+func TestPayoff_NoDiscountWithoutInterest(t *testing.T) {
+	iofCfg := defaultIOFConfig()
+	free := CalculateInstallmentPlan(30_000, 3, utcDate(2024, 1, 10), utcDate(2024, 2, 10), iofCfg, config.InstallmentConfig{})
+	merchant := CalculateInstallmentPlan(30_000, 3, utcDate(2024, 1, 10), utcDate(2024, 2, 10), iofCfg,
+		config.InstallmentConfig{MonthlyRate: 19_900, Funding: domain.FundingMerchant})
+
+	in := PayoffInput{Plans: []PayoffPlan{{Plan: free}, {Plan: merchant, MonthlyRate: 19_900}}}
+	q := CalculatePayoff(in, utcDate(2024, 1, 20), iofCfg, defaultInterestConfig(), defaultLateFeeConfig(),
+		defaultLateInterestConfig(), defaultRotativeRulesConfig(), config.PayoffConfig{})
+
+	if q.Discount != 0 || q.Total != free.TotalWithIOF+merchant.TotalWithIOF {
+		t.Fatalf("expected full amounts without discount, got total %d discount %d", q.Total, q.Discount)
+	}
+}
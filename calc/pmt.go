@@ -0,0 +1,90 @@
+package calc
+
+import (
+	"math/big"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// Precision of the Tabela Price path
+//
+// A rate is an exact fraction rate/D with D = RateDenominator, so
+// (1 + rate/D)^n equals B^n / D^n with B = D + rate. Powers are computed as
+// exact big.Int integers and every formula below is evaluated as a single
+// rational, rounded once at the end with the configured domain.RoundingMode:
+// pricePMT and compound are exact within ±0.5 centavo, for any n and rate.
+//
+// No intermediate rounding accumulates with n, and big.Int removes the int64
+// overflow of PV × (1+r)^n for large amounts or long plans.
+
+var rateDenominator = big.NewInt(domain.RateDenominator)
+
+// pricePMT returns the Tabela Price installment of pv over n monthly periods:
+//
+//	PMT = PV × r × (1+r)^n / ((1+r)^n − 1) = PV × rate × B^n / (D × (B^n − D^n))
+func pricePMT(pv domain.Money, rate domain.Rate, n int, mode domain.RoundingMode) domain.Money {
+	num, den := pricePMTExact(pv, rate, n)
+	return domain.Money(quoRound(num, den, mode).Int64())
+}
+
+// pricePMTExact returns the unrounded PMT as the fraction num/den.
+func pricePMTExact(pv domain.Money, rate domain.Rate, n int) (num, den *big.Int) {
+	bn, dn := ratePowers(rate, n)
+
+	num = new(big.Int).Mul(big.NewInt(int64(pv)), big.NewInt(int64(rate)))
+	num.Mul(num, bn)
+	den = new(big.Int).Sub(bn, dn)
+	den.Mul(den, rateDenominator)
+	return num, den
+}
+
+// compound returns pv × (1+r)^n, rounded with mode, and its rounding residue.
+func compound(pv domain.Money, rate domain.Rate, n int, mode domain.RoundingMode) (domain.Money, int64) {
+	bn, dn := ratePowers(rate, n)
+	num := new(big.Int).Mul(big.NewInt(int64(pv)), bn)
+	q := quoRound(num, dn, mode)
+	return domain.Money(q.Int64()), bigResidue(q, num, dn)
+}
+
+// ratePowers returns B^n and D^n, with B = D + rate and D = RateDenominator.
+func ratePowers(rate domain.Rate, n int) (bn, dn *big.Int) {
+	exp := big.NewInt(int64(n))
+	bn = new(big.Int).Exp(big.NewInt(domain.RateDenominator+int64(rate)), exp, nil)
+	dn = new(big.Int).Exp(rateDenominator, exp, nil)
+	return bn, dn
+}
+
+// quoRound returns num/den rounded with mode, for num >= 0 and den > 0.
+func quoRound(num, den *big.Int, mode domain.RoundingMode) *big.Int {
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 || mode == domain.RoundDown {
+		return q
+	}
+
+	switch new(big.Int).Lsh(rem, 1).Cmp(den) {
+	case 1:
+		q.Add(q, big.NewInt(1))
+	case 0:
+		if mode != domain.RoundHalfEven || q.Bit(0) == 1 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// bigResidue returns q − num/den in 1/ResidueDenominator of a centavo, rounded
+// to the nearest unit.
+func bigResidue(q, num, den *big.Int) int64 {
+	diff := new(big.Int).Mul(q, den)
+	diff.Sub(diff, num)
+	diff.Mul(diff, big.NewInt(domain.ResidueDenominator))
+
+	neg := diff.Sign() < 0
+	diff.Abs(diff)
+	diff.Add(diff, new(big.Int).Rsh(den, 1))
+	diff.Quo(diff, den)
+	if neg {
+		diff.Neg(diff)
+	}
+	return diff.Int64()
+}
@@ -0,0 +1,74 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// Reference Tabela Price installments, as published by bank calculators
+// (e.g. Calculadora do Cidadao, BCB) and textbook tables, in centavos.
+var pmtReference = []struct {
+	pv   domain.Money
+	rate domain.Rate
+	n    int
+	want domain.Money
+}{
+	{100_000, 10_000, 12, 8_885},        // R$ 1.000,00 a 1% a.m. em 12x = R$ 88,85
+	{1_000_000, 10_000, 12, 88_849},     // R$ 10.000,00 a 1% a.m. em 12x = R$ 888,49
+	{1_000_000, 20_000, 24, 52_871},     // R$ 10.000,00 a 2% a.m. em 24x = R$ 528,71
+	{100_000, 19_900, 12, 9_450},        // R$ 1.000,00 a 1,99% a.m. em 12x = R$ 94,50
+	{200_000, 35_000, 48, 8_661},        // R$ 2.000,00 a 3,5% a.m. em 48x = R$ 86,61
+	{1_000_000, 14_900, 60, 25_328},     // R$ 10.000,00 a 1,49% a.m. em 60x = R$ 253,28
+	{5_000_000, 19_900, 36, 195_849},    // R$ 50.000,00 a 1,99% a.m. em 36x = R$ 1.958,49
+	{10_000_000, 9_900, 12, 887_927},    // R$ 100.000,00 a 0,99% a.m. em 12x = R$ 8.879,27
+	{50_000_000, 19_900, 12, 4_725_077}, // R$ 500.000,00 a 1,99% a.m. em 12x = R$ 47.250,77
+	{50_000_000, 9_900, 60, 1_109_193},  // R$ 500.000,00 a 0,99% a.m. em 60x = R$ 11.091,93
+	{100_000, 199_900, 24, 20_245},      // R$ 1.000,00 a 19,99% a.m. em 24x = R$ 202,45
+}
+
+func TestPricePMT_ReferenceTable(t *testing.T) {
+	for _, tc := range pmtReference {
+		if got := pricePMT(tc.pv, tc.rate, tc.n, domain.RoundHalfUp); got != tc.want {
+			t.Fatalf("PMT(%d, %d, %d): expected %d, got %d", tc.pv, tc.rate, tc.n, tc.want, got)
+		}
+	}
+}
+
+func TestPricePMT_NoOverflowOnLargeAmounts(t *testing.T) {
+	// R$ 10 bilhoes: PV × (1+r)^n no longer fits int64 with 6-decimal powers.
+	got := pricePMT(1_000_000_000_000, 19_900, 48, domain.RoundHalfUp)
+	// Exact value: 32.535.530.802,83 centavos.
+	if got != 32_535_530_803 {
+		t.Fatalf("expected 32535530803, got %d", got)
+	}
+}
+
+func TestInstallment_LastInstallmentDriftBounded(t *testing.T) {
+	// The PMT is exact within half a centavo, so the last installment only
+	// absorbs the centavo rounding of the PMT and of each period's interest:
+	// at most about one centavo per period.
+	plan := CalculateInstallmentPlan(10_000_000, 60, utcDate(2024, 1, 5), utcDate(2024, 2, 5),
+		defaultIOFConfig(), config.InstallmentConfig{MonthlyRate: 9_900})
+
+	first := plan.Installments[0]
+	if first.Principal+first.Interest != 221_839 {
+		t.Fatalf("expected PMT 221839, got %d", first.Principal+first.Interest)
+	}
+
+	last := plan.Installments[59]
+	if d := last.Interest - mulRate(last.Principal, 9_900, domain.RoundHalfUp); d < -60 || d > 60 {
+		t.Fatalf("last installment absorbed %d centavos", d)
+	}
+}
+
+func TestCompound(t *testing.T) {
+	// R$ 1.000,00 × 1,0199^2 = R$ 1.040,19601
+	if got, _ := compound(100_000, 19_900, 2, domain.RoundHalfUp); got != 104_020 {
+		t.Fatalf("expected 104020, got %d", got)
+	}
+	if got, _ := compound(100_000, 19_900, 0, domain.RoundHalfUp); got != 100_000 {
+		t.Fatalf("expected 100000, got %d", got)
+	}
+}
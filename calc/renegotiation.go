@@ -0,0 +1,180 @@
+package calc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+var (
+	// ErrDownPaymentBelowMinimum is returned when the entrada is below
+	// RenegotiationConfig.MinDownPaymentRate of the renegotiated debt.
+	ErrDownPaymentBelowMinimum = errors.New("calc: down payment below the agreement minimum")
+	// ErrDownPaymentAboveDebt is returned when the entrada is negative or
+	// exceeds the renegotiated debt.
+	ErrDownPaymentAboveDebt = errors.New("calc: down payment outside the renegotiated debt")
+	// ErrInvalidAgreementInstallments is returned when a balance is left to
+	// finance with no installments, or with more than
+	// RenegotiationConfig.MaxInstallments.
+	ErrInvalidAgreementInstallments = errors.New("calc: invalid number of agreement installments")
+)
+
+// Debt is an overdue debt by bucket, e.g. a rotative balance and its charges.
+type Debt struct {
+	Principal    domain.Money
+	Interest     domain.Money
+	IOF          domain.Money
+	LateInterest domain.Money
+	LateFee      domain.Money
+}
+
+// DebtFromRotative returns the buckets of a rotative result.
+func DebtFromRotative(r RotativeResult) Debt {
+	return Debt{
+		Principal:    r.Principal,
+		Interest:     r.Interest,
+		IOF:          r.IOF,
+		LateInterest: r.LateInterest,
+		LateFee:      r.LateFee,
+	}
+}
+
+// Total returns the sum of the buckets.
+func (d Debt) Total() domain.Money {
+	return d.Principal + d.Interest + d.IOF + d.LateInterest + d.LateFee
+}
+
+func (d Debt) sub(o Debt) Debt {
+	return Debt{
+		Principal:    d.Principal - o.Principal,
+		Interest:     d.Interest - o.Interest,
+		IOF:          d.IOF - o.IOF,
+		LateInterest: d.LateInterest - o.LateInterest,
+		LateFee:      d.LateFee - o.LateFee,
+	}
+}
+
+// DebtOrigin is the debt an agreement replaces. It is kept in the result as
+// the lineage of the new plan.
+type DebtOrigin struct {
+	AccountID string
+	// Reference identifies the original debt for the caller (ledger), e.g.
+	// the invoice ID or the contract of a previous agreement.
+	Reference string
+	// Date is when Debt was computed, e.g. the CalculateRotative date.
+	Date time.Time
+	Debt Debt
+}
+
+// RenegotiationTerms are the conditions the customer accepts.
+type RenegotiationTerms struct {
+	// Date is the agreement date: the entrada is paid and the new plan is
+	// contracted on it.
+	Date        time.Time
+	DownPayment domain.Money // entrada
+	// Installments of the new plan. Zero when the entrada settles the
+	// renegotiated debt (acordo a vista).
+	Installments int
+	FirstDueDate time.Time
+}
+
+// Renegotiation is a debt agreement (acordo). Amounts trace back to the
+// original debt bucket by bucket:
+//
+//	Origin.Debt - Discount = Renegotiated
+//	Renegotiated - DownPaymentPaid = Financed
+//	Financed.Total() = Plan.TotalAmount
+//
+// The caller (ledger) should persist it with the original debt it replaces.
+type Renegotiation struct {
+	Origin       DebtOrigin
+	Terms        RenegotiationTerms
+	Discount     Debt // waived per bucket
+	Renegotiated Debt
+	// DownPaymentPaid is the entrada applied to the renegotiated buckets in
+	// the order of ApplyPayment (IOF, interest, late interest, late fee,
+	// principal).
+	DownPaymentPaid Debt
+	Financed        Debt
+	// Plan is the new installment plan of Financed.Total(), with its own
+	// IOF and the agreement rate. It is empty for an acordo a vista.
+	Plan domain.InstallmentPlan
+	// Total is what the customer pays: the entrada plus the plan with
+	// interest and IOF.
+	Total domain.Money
+}
+
+// CalculateRenegotiation prices an agreement for the debt of origin.
+//
+// Each bucket is discounted by its rate in renegCfg (principal, interest,
+// late interest and late fee); IOF is a tax already owed and is never
+// discounted. The entrada pays the renegotiated buckets in the order of
+// ApplyPayment and must be at least renegCfg.MinDownPaymentRate of the
+// renegotiated debt. What is left is financed in terms.Installments at
+// renegCfg.MonthlyRate (Tabela Price; sem juros when zero), as a new credit
+// operation with IOF from terms.Date, financed inside the parcels with
+// renegCfg.FinanceIOF.
+//
+// It returns ErrDownPaymentAboveDebt, ErrDownPaymentBelowMinimum or
+// ErrInvalidAgreementInstallments when the terms break the policy. Discount
+// rates above 100% and invalid dates are the caller's responsibility.
+func CalculateRenegotiation(
+	origin DebtOrigin,
+	terms RenegotiationTerms,
+	iofCfg config.IOFConfig,
+	renegCfg config.RenegotiationConfig,
+) (Renegotiation, error) {
+	mode := renegCfg.Rounding
+	debt := origin.Debt
+	discount := Debt{
+		Principal:    mulRate(debt.Principal, renegCfg.PrincipalDiscount, mode),
+		Interest:     mulRate(debt.Interest, renegCfg.InterestDiscount, mode),
+		LateInterest: mulRate(debt.LateInterest, renegCfg.LateInterestDiscount, mode),
+		LateFee:      mulRate(debt.LateFee, renegCfg.LateFeeDiscount, mode),
+	}
+	renegotiated := debt.sub(discount)
+	total := renegotiated.Total()
+
+	switch {
+	case terms.DownPayment < 0 || terms.DownPayment > total:
+		return Renegotiation{}, ErrDownPaymentAboveDebt
+	case terms.DownPayment < mulRate(total, renegCfg.MinDownPaymentRate, mode):
+		return Renegotiation{}, ErrDownPaymentBelowMinimum
+	case terms.DownPayment < total && terms.Installments < 1,
+		renegCfg.MaxInstallments > 0 && terms.Installments > renegCfg.MaxInstallments:
+		return Renegotiation{}, ErrInvalidAgreementInstallments
+	}
+
+	paid := ApplyPayment(total, renegotiated.IOF, renegotiated.Interest,
+		renegotiated.LateInterest, renegotiated.LateFee, renegotiated.Principal, terms.DownPayment)
+	downPayment := Debt{
+		Principal:    paid.PaidPrincipal,
+		Interest:     paid.PaidInterest,
+		IOF:          paid.PaidIOF,
+		LateInterest: paid.PaidLateInterest,
+		LateFee:      paid.PaidLateFee,
+	}
+	financed := renegotiated.sub(downPayment)
+
+	r := Renegotiation{
+		Origin:          origin,
+		Terms:           terms,
+		Discount:        discount,
+		Renegotiated:    renegotiated,
+		DownPaymentPaid: downPayment,
+		Financed:        financed,
+		Total:           terms.DownPayment,
+	}
+	if financed.Total() > 0 {
+		instCfg := config.InstallmentConfig{
+			MonthlyRate: renegCfg.MonthlyRate,
+			Rounding:    mode,
+			FinanceIOF:  renegCfg.FinanceIOF,
+		}
+		r.Plan = CalculateInstallmentPlan(financed.Total(), terms.Installments, terms.Date, terms.FirstDueDate, iofCfg, instCfg)
+		r.Total += r.Plan.TotalWithIOF
+	}
+	return r, nil
+}
@@ -0,0 +1,130 @@
+package calc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func defaultRenegotiationConfig() config.RenegotiationConfig {
+	return config.RenegotiationConfig{
+		InterestDiscount:     500_000,
+		LateInterestDiscount: 1_000_000,
+		LateFeeDiscount:      1_000_000,
+		MinDownPaymentRate:   100_000,
+		MonthlyRate:          49_900,
+		MaxInstallments:      24,
+	}
+}
+
+// overdueDebt is R$ 1.000,00 revolving for 30 days with the default configs.
+func overdueDebt() DebtOrigin {
+	return DebtOrigin{
+		AccountID: "acc-1",
+		Reference: "inv-2024-01",
+		Date:      utcDate(2024, 2, 9),
+		Debt:      Debt{Principal: 100_000, Interest: 12_000, IOF: 626, LateInterest: 1_000, LateFee: 2_000},
+	}
+}
+
+func TestCalculateRenegotiation(t *testing.T) {
+	terms := RenegotiationTerms{Date: utcDate(2024, 3, 1), DownPayment: 20_000, Installments: 6, FirstDueDate: utcDate(2024, 4, 1)}
+
+	r, err := CalculateRenegotiation(overdueDebt(), terms, defaultIOFConfig(), defaultRenegotiationConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Half the interest and all late charges are waived; IOF is kept.
+	if want := (Debt{Interest: 6_000, LateInterest: 1_000, LateFee: 2_000}); r.Discount != want {
+		t.Fatalf("expected discount %+v, got %+v", want, r.Discount)
+	}
+	if r.Renegotiated.Total() != 106_626 || r.Renegotiated.IOF != 626 {
+		t.Fatalf("expected renegotiated debt 106626 with IOF 626, got %+v", r.Renegotiated)
+	}
+	// The entrada pays IOF, then interest, then principal.
+	if want := (Debt{Principal: 13_374, Interest: 6_000, IOF: 626}); r.DownPaymentPaid != want {
+		t.Fatalf("expected entrada allocation %+v, got %+v", want, r.DownPaymentPaid)
+	}
+	if want := (Debt{Principal: 86_626}); r.Financed != want {
+		t.Fatalf("expected financed %+v, got %+v", want, r.Financed)
+	}
+
+	if r.Plan.TotalAmount != 86_626 || len(r.Plan.Installments) != 6 {
+		t.Fatalf("expected a 6x plan of 86626, got %d in %d", r.Plan.TotalAmount, len(r.Plan.Installments))
+	}
+	if r.Plan.TotalInterest != 15_740 || r.Plan.TotalIOF != 1_120 {
+		t.Fatalf("expected interest 15740 and IOF 1120, got %d and %d", r.Plan.TotalInterest, r.Plan.TotalIOF)
+	}
+	if r.Total != 123_486 || r.Total != terms.DownPayment+r.Plan.TotalWithIOF {
+		t.Fatalf("expected total 123486, got %d", r.Total)
+	}
+	if r.Origin != overdueDebt() || r.Terms != terms {
+		t.Fatalf("expected the origin and terms kept as lineage")
+	}
+}
+
+func TestCalculateRenegotiation_CashSettlement(t *testing.T) {
+	terms := RenegotiationTerms{Date: utcDate(2024, 3, 1), DownPayment: 106_626}
+
+	r, err := CalculateRenegotiation(overdueDebt(), terms, defaultIOFConfig(), defaultRenegotiationConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Financed.Total() != 0 || len(r.Plan.Installments) != 0 || r.Total != 106_626 {
+		t.Fatalf("expected the entrada to settle the debt, got financed %d and total %d", r.Financed.Total(), r.Total)
+	}
+}
+
+func TestCalculateRenegotiation_FinancedIOF(t *testing.T) {
+	cfg := defaultRenegotiationConfig()
+	cfg.FinanceIOF = true
+	terms := RenegotiationTerms{Date: utcDate(2024, 3, 1), DownPayment: 20_000, Installments: 6, FirstDueDate: utcDate(2024, 4, 1)}
+
+	r, err := CalculateRenegotiation(overdueDebt(), terms, defaultIOFConfig(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !r.Plan.IOFFinanced || r.Plan.TotalAmount != r.Financed.Total() {
+		t.Fatalf("expected a plan with financed IOF over %d, got %+v", r.Financed.Total(), r.Plan)
+	}
+	for _, inst := range r.Plan.Installments {
+		if inst.Amount != r.Plan.Installments[0].Amount {
+			t.Fatalf("expected equal parcels, got %d and %d", inst.Amount, r.Plan.Installments[0].Amount)
+		}
+	}
+}
+
+func TestCalculateRenegotiation_PolicyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		terms RenegotiationTerms
+		want  error
+	}{
+		{"entrada abaixo do minimo", RenegotiationTerms{DownPayment: 10_662, Installments: 6}, ErrDownPaymentBelowMinimum},
+		{"entrada acima da divida", RenegotiationTerms{DownPayment: 106_627}, ErrDownPaymentAboveDebt},
+		{"entrada negativa", RenegotiationTerms{DownPayment: -1, Installments: 6}, ErrDownPaymentAboveDebt},
+		{"saldo sem parcelas", RenegotiationTerms{DownPayment: 20_000}, ErrInvalidAgreementInstallments},
+		{"parcelas acima do maximo", RenegotiationTerms{DownPayment: 20_000, Installments: 25}, ErrInvalidAgreementInstallments},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.terms.Date, tc.terms.FirstDueDate = utcDate(2024, 3, 1), utcDate(2024, 4, 1)
+			if _, err := CalculateRenegotiation(overdueDebt(), tc.terms, defaultIOFConfig(), defaultRenegotiationConfig()); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestDebtFromRotative(t *testing.T) {
+	r := CalculateRotative(domain.RotativeBalance{Principal: 100_000, StartDate: utcDate(2024, 1, 10)}, utcDate(2024, 2, 9),
+		defaultIOFConfig(), defaultInterestConfig(), defaultLateFeeConfig(), defaultLateInterestConfig(), defaultRotativeRulesConfig())
+
+	if d := DebtFromRotative(r); d != overdueDebt().Debt || d.Total() != r.Total {
+		t.Fatalf("expected %+v with total %d, got %+v", overdueDebt().Debt, r.Total, d)
+	}
+}
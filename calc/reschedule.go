@@ -0,0 +1,285 @@
+package calc
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// RescheduleResult is a plan moved to a new invoice due day, with the diff
+// against the original plan. Deltas are new minus original.
+type RescheduleResult struct {
+	Original      domain.InstallmentPlan
+	Plan          domain.InstallmentPlan
+	Changes       []InstallmentChange // only installments whose due date or amounts changed
+	InterestDelta domain.Money
+	IOFDelta      domain.Money
+	AmountDelta   domain.Money
+}
+
+// InstallmentChange is the diff of one rescheduled installment.
+type InstallmentChange struct {
+	Number        int
+	OldDueDate    time.Time
+	NewDueDate    time.Time
+	DaysShift     int // positive when the due date moves later
+	InterestDelta domain.Money
+	IOFDelta      domain.Money
+	AmountDelta   domain.Money
+}
+
+// ReschedulePlan moves the future installments of plan to newDueDay.
+//
+// Calendar rules:
+//   - installments due on or before effectiveDate are already billed and keep
+//     their dates and amounts;
+//   - the first future installment keeps its month and takes newDueDay,
+//     clamped to the last day of the month (day 31 becomes Feb 28/29, Apr 30,
+//     ...); the next ones follow one per month, so month-end plans whose
+//     dates overflowed (Jan 31 + 1 month = Mar 2) get one parcel per month;
+//   - if the first future installment would then fall on or before
+//     effectiveDate, all future installments move one month forward.
+//
+// IOF of each moved installment is recomputed for the new number of days since
+// purchaseDate. For interest-bearing issuer plans the outstanding principal
+// accrues (or stops accruing) pro rata interest for the days the first moved
+// installment shifts, added to that installment; later installments keep
+// monthly spacing and their interest. Merchant-funded plans have no interest
+// nor IOF to adjust.
+//
+// Plans with the IOF financed (plan.IOFFinanced) keep equal parcels: the
+// future installments are refinanced on their new due dates, grossing up the
+// outstanding principal plus the pro rata interest as CalculateInstallmentPlan
+// does, and every future installment may change.
+//
+// Input validation (newDueDay between 1 and 31, plan built from purchaseDate)
+// is the caller's responsibility.
+func ReschedulePlan(
+	plan domain.InstallmentPlan,
+	purchaseDate time.Time,
+	effectiveDate time.Time,
+	newDueDay int,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+) RescheduleResult {
+	installments := make([]domain.Installment, len(plan.Installments))
+	copy(installments, plan.Installments)
+
+	first := -1
+	for i, inst := range installments {
+		if inst.DueDate.After(effectiveDate) {
+			first = i
+			break
+		}
+	}
+
+	result := RescheduleResult{Original: plan}
+	if first < 0 {
+		result.Plan = plan
+		result.Plan.Installments = installments
+		return result
+	}
+
+	shift := 0
+	if !withDay(installments[first].DueDate, newDueDay, 0).After(effectiveDate) {
+		shift = 1
+	}
+
+	merchant := plan.Funding == domain.FundingMerchant
+	var outstanding domain.Money
+	for _, inst := range installments[first:] {
+		outstanding += inst.Principal
+		if plan.IOFFinanced {
+			// The financed IOF is part of the balance.
+			outstanding += inst.IOF
+		}
+	}
+
+	firstMoved := -1
+	for i := first; i < len(installments); i++ {
+		installments[i].DueDate = withDay(plan.Installments[first].DueDate, newDueDay, shift+i-first)
+		if firstMoved < 0 && !installments[i].DueDate.Equal(plan.Installments[i].DueDate) {
+			firstMoved = i
+		}
+	}
+
+	if firstMoved >= 0 && !merchant {
+		var shiftInterest domain.Money
+		if firstMoved == first && instCfg.MonthlyRate != 0 {
+			old := plan.Installments[firstMoved]
+			daysShift := int(installments[firstMoved].DueDate.Sub(old.DueDate).Hours() / 24)
+			// Never more than the interest the installment had.
+			shiftInterest = max(proRataInterest(outstanding, instCfg.MonthlyRate, daysShift, instCfg.Rounding), -old.Interest)
+		}
+
+		if plan.IOFFinanced {
+			refinance(installments[first:], purchaseDate, shiftInterest, iofCfg, instCfg)
+		} else {
+			for i := firstMoved; i < len(installments); i++ {
+				inst := &installments[i]
+				if inst.DueDate.Equal(plan.Installments[i].DueDate) {
+					continue
+				}
+				inst.IOF = CalculateIOF(inst.Principal, daysBetween(purchaseDate, inst.DueDate), iofCfg)
+				if i == firstMoved {
+					inst.Interest += shiftInterest
+				}
+				inst.Amount = inst.Principal + inst.Interest + inst.IOF
+			}
+		}
+	}
+
+	for i := first; i < len(installments); i++ {
+		inst, old := installments[i], plan.Installments[i]
+		if inst == old {
+			continue
+		}
+		change := InstallmentChange{
+			Number:        inst.Number,
+			OldDueDate:    old.DueDate,
+			NewDueDate:    inst.DueDate,
+			DaysShift:     int(inst.DueDate.Sub(old.DueDate).Hours() / 24),
+			InterestDelta: inst.Interest - old.Interest,
+			IOFDelta:      inst.IOF - old.IOF,
+			AmountDelta:   inst.Amount - old.Amount,
+		}
+		result.Changes = append(result.Changes, change)
+		result.InterestDelta += change.InterestDelta
+		result.IOFDelta += change.IOFDelta
+		result.AmountDelta += change.AmountDelta
+	}
+
+	result.Plan = plan
+	result.Plan.Installments = installments
+	result.Plan.TotalInterest += result.InterestDelta
+	result.Plan.TotalIOF += result.IOFDelta
+	result.Plan.TotalWithIOF += result.AmountDelta
+	return result
+}
+
+// refinance rebuilds future, already on its new due dates, as a plan with the
+// IOF financed: the outstanding purchase principal plus shiftInterest is
+// grossed up by the IOF of its own amortization schedule on the new dates, so
+// the parcels are equal again.
+func refinance(future []domain.Installment, purchaseDate time.Time, shiftInterest domain.Money, iofCfg config.IOFConfig, instCfg config.InstallmentConfig) {
+	var principal domain.Money
+	for _, inst := range future {
+		principal += inst.Principal
+	}
+	dueDates := make([]time.Time, len(future))
+	for i, inst := range future {
+		dueDates[i] = inst.DueDate
+	}
+
+	base := principal + shiftInterest
+	financed := base
+	amortized, interest, iof := amortize(financed, shiftInterest, dueDates, purchaseDate, iofCfg, instCfg)
+	for range maxGrossUpIterations {
+		next := base + sumMoney(iof)
+		if next <= financed {
+			break
+		}
+		financed = next
+		amortized, interest, iof = amortize(financed, shiftInterest, dueDates, purchaseDate, iofCfg, instCfg)
+	}
+
+	// As in calculateFinancedIOF, rounding can leave the financed IOF a
+	// centavo or so above the IOF of the schedule.
+	extra := financed - base - sumMoney(iof)
+	for i := len(future) - 1; extra > 0 && i >= 0; i-- {
+		add := min(extra, amortized[i]-iof[i])
+		iof[i] += add
+		extra -= add
+	}
+
+	for i := range future {
+		inst := &future[i]
+		inst.Principal = amortized[i] - iof[i]
+		inst.Interest = interest[i]
+		inst.IOF = iof[i]
+		inst.Amount = inst.Principal + inst.Interest + inst.IOF
+	}
+}
+
+// amortize returns, for each installment of financed due on dueDates, the
+// principal amortized (purchase principal plus financed IOF), the interest
+// and the IOF of that principal: Tabela Price at instCfg.MonthlyRate, or equal
+// parts when it is zero. shiftInterest is capitalized like grace interest:
+// reported as interest and amortized first or, when negative, taken off the
+// first interests.
+func amortize(financed, shiftInterest domain.Money, dueDates []time.Time, purchaseDate time.Time, iofCfg config.IOFConfig, instCfg config.InstallmentConfig) (amortized, interest, iof []domain.Money) {
+	n := len(dueDates)
+	amortized = make([]domain.Money, n)
+	interest = make([]domain.Money, n)
+	iof = make([]domain.Money, n)
+
+	r, mode := instCfg.MonthlyRate, instCfg.Rounding
+	if r == 0 {
+		base := financed / domain.Money(n)
+		for i := range amortized {
+			amortized[i] = base
+		}
+		remainderIdx := 0
+		if instCfg.Remainder == domain.RemainderLast {
+			remainderIdx = n - 1
+		}
+		amortized[remainderIdx] += financed - base*domain.Money(n)
+	} else {
+		pmtNum, pmtDen := pricePMTExact(financed, r, n)
+		pmt := domain.Money(quoRound(pmtNum, pmtDen, mode).Int64())
+		balance := financed
+		for i := range n {
+			interest[i] = mulRate(balance, r, mode)
+			amortized[i] = min(pmt-interest[i], balance)
+			if i == n-1 {
+				amortized[i] = balance
+				interest[i] = pmt - balance
+				if interest[i] < 0 {
+					interest[i] = mulRate(balance, r, mode)
+				}
+			}
+			balance -= amortized[i]
+		}
+	}
+
+	pending := shiftInterest
+	for i, due := range dueDates {
+		var move domain.Money
+		if pending > 0 {
+			move = min(pending, amortized[i])
+		} else {
+			move = -min(-pending, interest[i])
+		}
+		amortized[i] -= move
+		interest[i] += move
+		pending -= move
+		iof[i] = CalculateIOF(amortized[i], daysBetween(purchaseDate, due), iofCfg)
+	}
+	return amortized, interest, iof
+}
+
+func sumMoney(values []domain.Money) domain.Money {
+	var total domain.Money
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// withDay returns the date months after t's month with the given day,
+// clamped to the last day of that month.
+func withDay(t time.Time, day, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	return firstOfMonth.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+// proRataInterest returns principal × monthlyRate × days / 30 rounded with
+// mode, symmetric for negative days.
+func proRataInterest(principal domain.Money, monthlyRate domain.Rate, days int, mode domain.RoundingMode) domain.Money {
+	if days < 0 {
+		return -proRataInterest(principal, monthlyRate, -days, mode)
+	}
+	return CalculateRotativeInterest(principal, days, config.InterestConfig{MonthlyRate: monthlyRate, Rounding: mode})
+}
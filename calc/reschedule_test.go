@@ -0,0 +1,174 @@
+package calc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestReschedule_MovesFutureInstallmentsOnly(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	iofCfg := defaultIOFConfig()
+	instCfg := config.InstallmentConfig{MonthlyRate: 0}
+	plan := CalculateInstallmentPlan(120_000, 6, purchaseDate, utcDate(2024, 2, 10), iofCfg, instCfg)
+
+	// Installments due Feb 10 and Mar 10 are already billed.
+	res := ReschedulePlan(plan, purchaseDate, utcDate(2024, 3, 15), 20, iofCfg, instCfg)
+
+	for i := 0; i < 2; i++ {
+		if res.Plan.Installments[i] != plan.Installments[i] {
+			t.Fatalf("installment %d: billed installment changed", i+1)
+		}
+	}
+	if len(res.Changes) != 4 {
+		t.Fatalf("expected 4 changes, got %d", len(res.Changes))
+	}
+	for i, c := range res.Changes {
+		want := utcDate(2024, time.Month(4+i), 20)
+		if !c.NewDueDate.Equal(want) || c.DaysShift != 10 {
+			t.Fatalf("change %d: expected %v (+10 days), got %v (%+d)", i, want, c.NewDueDate, c.DaysShift)
+		}
+		// Later due dates mean more IOF days.
+		if c.IOFDelta <= 0 || c.InterestDelta != 0 {
+			t.Fatalf("change %d: expected IOF increase only, got %+v", i, c)
+		}
+	}
+	if res.Plan.TotalWithIOF != plan.TotalWithIOF+res.AmountDelta {
+		t.Fatalf("totals not updated: %d vs %d + %d", res.Plan.TotalWithIOF, plan.TotalWithIOF, res.AmountDelta)
+	}
+	if plan.Installments[2].DueDate.Day() != 10 {
+		t.Fatalf("original plan was modified")
+	}
+}
+
+func TestReschedule_ClampsToMonthEnd(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	iofCfg := defaultIOFConfig()
+	instCfg := config.InstallmentConfig{MonthlyRate: 0}
+	plan := CalculateInstallmentPlan(90_000, 3, purchaseDate, utcDate(2024, 2, 10), iofCfg, instCfg)
+
+	res := ReschedulePlan(plan, purchaseDate, purchaseDate, 31, iofCfg, instCfg)
+
+	want := []time.Time{utcDate(2024, 2, 29), utcDate(2024, 3, 31), utcDate(2024, 4, 30)}
+	for i, inst := range res.Plan.Installments {
+		if !inst.DueDate.Equal(want[i]) {
+			t.Fatalf("installment %d: expected %v, got %v", i+1, want[i], inst.DueDate)
+		}
+	}
+}
+
+func TestReschedule_MonthEndPlanKeepsOneParcelPerMonth(t *testing.T) {
+	// Jan 31 + 1 month overflows to Mar 2, so the plan has no February parcel.
+	purchaseDate := utcDate(2024, 1, 1)
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900}
+	plan := CalculateInstallmentPlan(100_000, 4, purchaseDate, utcDate(2024, 1, 31), defaultIOFConfig(), instCfg)
+
+	res := ReschedulePlan(plan, purchaseDate, utcDate(2024, 1, 5), 10, defaultIOFConfig(), instCfg)
+
+	want := []time.Time{utcDate(2024, 1, 10), utcDate(2024, 2, 10), utcDate(2024, 3, 10), utcDate(2024, 4, 10)}
+	for i, inst := range res.Plan.Installments {
+		if !inst.DueDate.Equal(want[i]) {
+			t.Fatalf("installment %d: expected %s, got %s", i+1, want[i].Format(time.DateOnly), inst.DueDate.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestReschedule_EarlierDayPastEffectiveDateMovesToNextMonth(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	iofCfg := defaultIOFConfig()
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900}
+	plan := CalculateInstallmentPlan(100_000, 3, purchaseDate, utcDate(2024, 2, 20), iofCfg, instCfg)
+
+	// On Feb 10 the customer moves from day 20 to day 5: Feb 5 has passed.
+	res := ReschedulePlan(plan, purchaseDate, utcDate(2024, 2, 10), 5, iofCfg, instCfg)
+
+	first := res.Changes[0]
+	if !first.NewDueDate.Equal(utcDate(2024, 3, 5)) || first.DaysShift != 14 {
+		t.Fatalf("expected first parcel on 2024-03-05 (+14 days), got %v (%+d)", first.NewDueDate, first.DaysShift)
+	}
+	// 100000 × 1.99% × 14/30 = 928.67
+	if first.InterestDelta != 929 {
+		t.Fatalf("expected interest delta 929, got %d", first.InterestDelta)
+	}
+	if res.Changes[1].InterestDelta != 0 {
+		t.Fatalf("expected no interest delta after first moved parcel, got %d", res.Changes[1].InterestDelta)
+	}
+}
+
+func TestReschedule_ProRataInterestUsesPlanRounding(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	iofCfg := defaultIOFConfig()
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900, Rounding: domain.RoundDown}
+	plan := CalculateInstallmentPlan(100_000, 3, purchaseDate, utcDate(2024, 2, 20), iofCfg, instCfg)
+
+	res := ReschedulePlan(plan, purchaseDate, utcDate(2024, 2, 10), 5, iofCfg, instCfg)
+
+	// 100000 × 1.99% × 14/30 = 928.67, truncated.
+	if got := res.Changes[0].InterestDelta; got != 928 {
+		t.Fatalf("expected interest delta 928, got %d", got)
+	}
+}
+
+func TestReschedule_EarlierDayReducesInterest(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	iofCfg := defaultIOFConfig()
+	instCfg := config.InstallmentConfig{MonthlyRate: 19_900}
+	plan := CalculateInstallmentPlan(100_000, 3, purchaseDate, utcDate(2024, 2, 20), iofCfg, instCfg)
+
+	res := ReschedulePlan(plan, purchaseDate, purchaseDate, 10, iofCfg, instCfg)
+
+	if res.InterestDelta >= 0 || res.IOFDelta >= 0 {
+		t.Fatalf("expected interest and IOF to decrease, got %+d / %+d", res.InterestDelta, res.IOFDelta)
+	}
+}
+
+func TestReschedule_MerchantFundedOnlyMovesDates(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	instCfg := config.InstallmentConfig{Funding: domain.FundingMerchant}
+	plan := CalculateInstallmentPlan(100_000, 4, purchaseDate, utcDate(2024, 2, 10), defaultIOFConfig(), instCfg)
+
+	res := ReschedulePlan(plan, purchaseDate, purchaseDate, 25, defaultIOFConfig(), instCfg)
+
+	if len(res.Changes) != 4 || res.AmountDelta != 0 {
+		t.Fatalf("expected 4 date-only changes, got %d changes, amount delta %d", len(res.Changes), res.AmountDelta)
+	}
+}
+
+func TestReschedule_FinancedIOFKeepsEqualParcels(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	iofCfg := defaultIOFConfig()
+	for _, rate := range []domain.Rate{0, 19_900} {
+		instCfg := config.InstallmentConfig{MonthlyRate: rate, FinanceIOF: true}
+		plan := CalculateInstallmentPlan(1_000_000, 6, purchaseDate, utcDate(2024, 2, 10), iofCfg, instCfg)
+
+		// The Feb 10 parcel is billed; the other five move from day 10 to 20.
+		res := ReschedulePlan(plan, purchaseDate, utcDate(2024, 2, 15), 20, iofCfg, instCfg)
+
+		if res.Plan.Installments[0] != plan.Installments[0] || len(res.Changes) != 5 {
+			t.Fatalf("rate %d: expected the billed parcel kept and 5 changes, got %d", rate, len(res.Changes))
+		}
+		if res.IOFDelta <= 0 || (rate != 0) != (res.InterestDelta > 0) {
+			t.Fatalf("rate %d: unexpected deltas: interest %+d, IOF %+d", rate, res.InterestDelta, res.IOFDelta)
+		}
+
+		var principal, iof, total domain.Money
+		for i, inst := range res.Plan.Installments {
+			principal += inst.Principal
+			iof += inst.IOF
+			total += inst.Amount
+			if i > 0 && inst.Amount != res.Plan.Installments[1].Amount {
+				t.Fatalf("rate %d: parcel %d is %d, expected equal parcels of %d", rate, i+1, inst.Amount, res.Plan.Installments[1].Amount)
+			}
+			// The IOF covers the IOF of the amortized principal on the new date.
+			if due := CalculateIOF(inst.Principal+inst.IOF, daysBetween(purchaseDate, inst.DueDate), iofCfg); inst.IOF < due {
+				t.Fatalf("rate %d: parcel %d IOF %d below %d", rate, i+1, inst.IOF, due)
+			}
+		}
+		if principal != plan.TotalAmount || iof != res.Plan.TotalIOF || total != res.Plan.TotalWithIOF {
+			t.Fatalf("rate %d: totals do not add up: principal %d, IOF %d/%d, total %d/%d",
+				rate, principal, iof, res.Plan.TotalIOF, total, res.Plan.TotalWithIOF)
+		}
+	}
+}
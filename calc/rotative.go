@@ -8,7 +8,8 @@ import (
 )
 
 // RotativeResult contains the breakdown of rotative credit charges.
-// The caller (ledger) should persist this struct for audit trail purposes.
+// The caller (ledger) should persist this struct for audit trail purposes,
+// sealed with audit.SealRotative so inputs and config are kept with it.
 type RotativeResult struct {
 	Principal    domain.Money
 	Interest     domain.Money
@@ -20,10 +21,20 @@ type RotativeResult struct {
 	Days         int
 	ChargedDays  int
 	ChargeCapped bool
+	// RoundingResidue is the sum of the charges as rounded minus their exact
+	// values, in 1/domain.ResidueDenominator of a centavo. Positive means the
+	// customer pays more than the exact amount.
+	RoundingResidue int64
+	// Override identifies the negotiated rates used, if any. It is set by the
+	// service layer; CalculateRotative itself always leaves it nil.
+	Override *domain.OverrideRef
 }
 
 // CalculateRotative computes all charges for a rotative credit balance.
 //
+// With RotativeRulesConfig.MaxChargeRate > 0, charges never exceed
+// MaxChargeRate × principal, unless IOF alone does.
+//
 // Input validation (non-negative principal, valid dates) is the caller's responsibility.
 func CalculateRotative(
 	balance domain.RotativeBalance,
@@ -44,39 +55,54 @@ func CalculateRotative(
 		chargedDays = rulesCfg.MaxDays
 	}
 
-	interest := CalculateRotativeInterest(balance.Principal, chargedDays, intCfg)
-	iof := CalculateIOF(balance.Principal, chargedDays, iofCfg)
+	interest, interestResidue := rotativeInterest(balance.Principal, chargedDays, intCfg)
+	iof, iofResidue := creditIOF(balance.Principal, chargedDays, iofCfg)
 
-	var lateFee domain.Money
-	var lateInterest domain.Money
+	var lateFeeAmt, lateInterestAmt domain.Money
+	var lateFeeResidue, lateInterestResidue int64
 	if days > 0 {
-		lateFee = CalculateLateFee(balance.Principal, lateFeeCfg)
-		lateInterest = CalculateLateInterest(balance.Principal, chargedDays, lateInterestCfg)
+		lateFeeAmt, lateFeeResidue = lateFee(balance.Principal, lateFeeCfg)
+		lateInterestAmt, lateInterestResidue = lateInterest(balance.Principal, chargedDays, lateInterestCfg)
 	}
+	residue := interestResidue + iofResidue + lateFeeResidue + lateInterestResidue
 
-	charges := interest + iof + lateFee + lateInterest
+	charges := interest + iof + lateFeeAmt + lateInterestAmt
 	chargeCapped := false
 	if rulesCfg.MaxChargeRate > 0 {
-		maxCharges := mulRate(balance.Principal, rulesCfg.MaxChargeRate)
+		maxCharges, maxResidue := roundProduct(domain.RateDenominator, rulesCfg.Rounding, int64(balance.Principal), int64(rulesCfg.MaxChargeRate))
 		if charges > maxCharges {
 			chargeCapped = true
-			interest = max(maxCharges-(iof+lateFee+lateInterest), 0)
-			charges = interest + iof + lateFee + lateInterest
+			// The excess comes off interest, then late interest, then the late
+			// fee. IOF is a tax and is never reduced.
+			excess := charges - maxCharges
+			for _, bucket := range []*domain.Money{&interest, &lateInterestAmt, &lateFeeAmt} {
+				cut := min(*bucket, excess)
+				*bucket -= cut
+				excess -= cut
+			}
+			charges = interest + iof + lateFeeAmt + lateInterestAmt
+			// The charges are the rounded cap, or IOF alone when it exceeds it.
+			if charges == maxCharges {
+				residue = maxResidue
+			} else {
+				residue = iofResidue
+			}
 		}
 	}
 
 	total := balance.Principal + charges
 
 	return RotativeResult{
-		Principal:    balance.Principal,
-		Interest:     interest,
-		IOF:          iof,
-		LateFee:      lateFee,
-		LateInterest: lateInterest,
-		Charges:      charges,
-		Total:        total,
-		Days:         days,
-		ChargedDays:  chargedDays,
-		ChargeCapped: chargeCapped,
+		Principal:       balance.Principal,
+		Interest:        interest,
+		IOF:             iof,
+		LateFee:         lateFeeAmt,
+		LateInterest:    lateInterestAmt,
+		Charges:         charges,
+		Total:           total,
+		Days:            days,
+		ChargedDays:     chargedDays,
+		ChargeCapped:    chargeCapped,
+		RoundingResidue: residue,
 	}
 }
@@ -88,3 +88,59 @@ func TestCalculateRotative_ChargeCap(t *testing.T) {
 		t.Fatalf("expected charge cap to be applied")
 	}
 }
+
+func TestCalculateRotative_ChargeCapCutsLateChargesKeepsIOF(t *testing.T) {
+	balance := domain.RotativeBalance{
+		Principal: 100_000,
+		StartDate: utcDate(2024, 1, 1),
+	}
+
+	// Uncapped: IOF 626, interest 12000, late fee 2000, late interest 1000.
+	rules := config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 10_000}
+	result := CalculateRotative(
+		balance,
+		utcDate(2024, 1, 31),
+		defaultIOFConfig(),
+		defaultInterestConfig(),
+		defaultLateFeeConfig(),
+		defaultLateInterestConfig(),
+		rules,
+	)
+
+	if result.Charges != 1_000 || !result.ChargeCapped {
+		t.Fatalf("expected charges capped at 1000 got %d", result.Charges)
+	}
+	if result.IOF != 626 || result.Interest != 0 || result.LateInterest != 0 || result.LateFee != 374 {
+		t.Fatalf("expected IOF 626, interest 0, late interest 0, late fee 374 got %+v", result)
+	}
+}
+
+func TestCalculateRotative_RoundingModeAndResidue(t *testing.T) {
+	balance := domain.RotativeBalance{Principal: 123_457, StartDate: utcDate(2024, 1, 10)}
+	calcDate := utcDate(2024, 2, 9) // 30 days
+
+	run := func(mode domain.RoundingMode, maxChargeRate domain.Rate) RotativeResult {
+		iofCfg, intCfg := defaultIOFConfig(), defaultInterestConfig()
+		lateFeeCfg, lateInterestCfg := defaultLateFeeConfig(), defaultLateInterestConfig()
+		rulesCfg := defaultRotativeRulesConfig()
+		iofCfg.Rounding, intCfg.Rounding, lateFeeCfg.Rounding, lateInterestCfg.Rounding, rulesCfg.Rounding = mode, mode, mode, mode, mode
+		rulesCfg.MaxChargeRate = maxChargeRate
+		return CalculateRotative(balance, calcDate, iofCfg, intCfg, lateFeeCfg, lateInterestCfg, rulesCfg)
+	}
+
+	// Exact: interest 14814.84, IOF 303.70422 + 469.1366, multa 2469.14, mora 1234.57.
+	halfUp := run(domain.RoundHalfUp, 1_000_000)
+	if halfUp.Charges != 19_292 || halfUp.RoundingResidue != 609_180 {
+		t.Fatalf("half-up: expected charges 19292 residue 609180, got %d / %d", halfUp.Charges, halfUp.RoundingResidue)
+	}
+	truncated := run(domain.RoundDown, 1_000_000)
+	if truncated.Charges != 19_289 || truncated.RoundingResidue != -2_390_820 {
+		t.Fatalf("truncate: expected charges 19289 residue -2390820, got %d / %d", truncated.Charges, truncated.RoundingResidue)
+	}
+
+	// Capped at 10%: the charges are the rounded cap, 12345.7 -> 12346.
+	capped := run(domain.RoundHalfUp, 100_000)
+	if !capped.ChargeCapped || capped.Charges != 12_346 || capped.RoundingResidue != 300_000 {
+		t.Fatalf("capped: expected charges 12346 residue 300000, got %+v", capped)
+	}
+}
@@ -0,0 +1,176 @@
+package calc
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// ParcelTarget is the parcel an inverse solver must fit, e.g. "10x de R$ 99,90".
+// A plan fits when none of its parcels exceeds Amount.
+type ParcelTarget struct {
+	Amount domain.Money
+	// IncludeIOF compares principal + interest + IOF with Amount; otherwise
+	// principal + interest, as shown at checkout. With the IOF financed
+	// (InstallmentConfig.FinanceIOF) the parcel always includes it.
+	IncludeIOF bool
+}
+
+// ParcelSolution is the plan found by an inverse solver.
+type ParcelSolution struct {
+	Principal    domain.Money // financed amount
+	DownPayment  domain.Money // entrada; only set by SolveDownPayment
+	Installments int
+	Parcel       domain.Money // largest parcel of Plan, as compared with the target
+	Plan         domain.InstallmentPlan
+}
+
+// SolvePrincipal returns the largest financed amount whose plan of n
+// installments fits the target. Plans are built with CalculateInstallmentPlan,
+// so IOF, grace period, funding and rounding are the ones of the regular plan.
+//
+// Interest-free and merchant-funded plans are solved exactly: the largest
+// equal principal that fits, then the largest remainder the remainder parcel
+// still fits. Tabela Price plans are solved by bisection on the amount; their
+// parcels absorb centavo adjustments, so an amount a centavo or two above the
+// result may also fit, but the result always does.
+//
+// With the IOF financed the parcels are those of the regular plan of the
+// grossed-up amount, so that amount is solved first and the result is it
+// less its IOF. The gross-up rounding does not grow monotonically with the
+// amount, so the result is then moved down to the largest amount that fits
+// and up while any of the next n centavos still fits.
+//
+// Input validation (n >= 1, valid dates) is the caller's responsibility; a
+// target below one centavo yields a zero principal.
+func SolvePrincipal(
+	target ParcelTarget,
+	n int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+) ParcelSolution {
+	fits := func(amount domain.Money) bool {
+		return LargestParcel(CalculateInstallmentPlan(amount, n, purchaseDate, firstDueDate, iofCfg, instCfg), target.IncludeIOF) <= target.Amount
+	}
+
+	var principal domain.Money
+	switch {
+	case target.Amount <= 0:
+	case instCfg.Funding == domain.FundingMerchant || (instCfg.MonthlyRate == 0 && !instCfg.FinanceIOF):
+		// Every parcel is at least its principal, so the equal principal
+		// never exceeds the target.
+		base := bisectMoney(0, target.Amount, func(b domain.Money) bool { return fits(b * domain.Money(n)) })
+		remainder := bisectMoney(0, domain.Money(n-1), func(r domain.Money) bool { return fits(base*domain.Money(n) + r) })
+		principal = base*domain.Money(n) + remainder
+	case instCfg.FinanceIOF:
+		regular := instCfg
+		regular.FinanceIOF = false
+		grossedUp := SolvePrincipal(ParcelTarget{Amount: target.Amount}, n, purchaseDate, firstDueDate, iofCfg, regular)
+		principal = grossedUp.Principal - grossedUp.Plan.TotalIOF
+		for principal > 0 && !fits(principal) {
+			principal--
+		}
+		for step := domain.Money(1); step <= domain.Money(n); step++ {
+			if fits(principal + step) {
+				principal += step
+				step = 0
+			}
+		}
+	default:
+		// Principals sum to the amount, so some parcel is at least amount/n.
+		principal = bisectMoney(0, target.Amount*domain.Money(n), fits)
+	}
+	return solution(principal, n, purchaseDate, firstDueDate, iofCfg, instCfg, target.IncludeIOF)
+}
+
+// SolveInstallments returns the smallest number of installments, from 1 to
+// maxInstallments, whose plan of amount fits the target. It reports false if
+// no plan fits.
+func SolveInstallments(
+	amount domain.Money,
+	target ParcelTarget,
+	maxInstallments int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+) (ParcelSolution, bool) {
+	for n := 1; n <= maxInstallments; n++ {
+		s := solution(amount, n, purchaseDate, firstDueDate, iofCfg, instCfg, target.IncludeIOF)
+		if s.Parcel <= target.Amount {
+			return s, true
+		}
+	}
+	return ParcelSolution{}, false
+}
+
+// SolveDownPayment returns the smallest down payment (entrada) for which the
+// rest of amount, financed in n installments, fits the target. The down
+// payment is paid upfront and bears neither interest nor IOF; with a target
+// below one centavo it is the whole amount.
+func SolveDownPayment(
+	amount domain.Money,
+	target ParcelTarget,
+	n int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+) ParcelSolution {
+	s := solution(amount, n, purchaseDate, firstDueDate, iofCfg, instCfg, target.IncludeIOF)
+	if s.Parcel > target.Amount {
+		s = SolvePrincipal(target, n, purchaseDate, firstDueDate, iofCfg, instCfg)
+	}
+	s.DownPayment = amount - s.Principal
+	return s
+}
+
+func solution(
+	principal domain.Money,
+	n int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	iofCfg config.IOFConfig,
+	instCfg config.InstallmentConfig,
+	includeIOF bool,
+) ParcelSolution {
+	plan := CalculateInstallmentPlan(principal, n, purchaseDate, firstDueDate, iofCfg, instCfg)
+	return ParcelSolution{
+		Principal:    principal,
+		Installments: n,
+		Parcel:       LargestParcel(plan, includeIOF),
+		Plan:         plan,
+	}
+}
+
+// LargestParcel returns the largest principal + interest of the plan, plus
+// the IOF with includeIOF or when the IOF is financed: the parcel a checkout
+// shows. It is not always the first one (RemainderLast, the last Price parcel
+// absorbing rounding).
+func LargestParcel(plan domain.InstallmentPlan, includeIOF bool) domain.Money {
+	var largest domain.Money
+	for _, inst := range plan.Installments {
+		parcel := inst.Principal + inst.Interest
+		if includeIOF || plan.IOFFinanced {
+			parcel += inst.IOF
+		}
+		largest = max(largest, parcel)
+	}
+	return largest
+}
+
+// bisectMoney returns the largest v in [lo, hi] with ok(v), given ok(lo).
+func bisectMoney(lo, hi domain.Money, ok func(domain.Money) bool) domain.Money {
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if ok(mid) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
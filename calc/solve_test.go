@@ -0,0 +1,129 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestSolvePrincipal(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	firstDueDate := utcDate(2024, 2, 5)
+	free := config.InstallmentConfig{}
+	price := config.InstallmentConfig{MonthlyRate: 19_900}
+
+	tests := []struct {
+		name    string
+		instCfg config.InstallmentConfig
+		target  ParcelTarget
+		want    domain.Money
+	}{
+		{"sem juros", free, ParcelTarget{Amount: 9_990}, 99_900},
+		{"sem juros com IOF", free, ParcelTarget{Amount: 9_990, IncludeIOF: true}, 97_109},
+		// PV of 10 × R$ 99,90 at 1,99% a.m. = R$ 897,87.
+		{"price", price, ParcelTarget{Amount: 9_990}, 89_787},
+		{"price com IOF", price, ParcelTarget{Amount: 9_990, IncludeIOF: true}, 87_324},
+		{"target zero", price, ParcelTarget{}, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := SolvePrincipal(tc.target, 10, purchaseDate, firstDueDate, defaultIOFConfig(), tc.instCfg)
+			if s.Principal != tc.want || s.Plan.TotalAmount != tc.want {
+				t.Fatalf("expected principal %d, got %d", tc.want, s.Principal)
+			}
+			if s.Parcel > tc.target.Amount {
+				t.Fatalf("parcel %d exceeds target %d", s.Parcel, tc.target.Amount)
+			}
+			next := CalculateInstallmentPlan(s.Principal+1, 10, purchaseDate, firstDueDate, defaultIOFConfig(), tc.instCfg)
+			if LargestParcel(next, tc.target.IncludeIOF) <= tc.target.Amount && tc.target.Amount > 0 {
+				t.Fatalf("principal %d also fits", s.Principal+1)
+			}
+		})
+	}
+}
+
+func TestSolvePrincipal_RemainderParcel(t *testing.T) {
+	// 3x of R$ 10,00 with IOF: the last parcel (most days of IOF) limits the
+	// equal principal to 989; the first parcel, with less IOF, takes the
+	// largest remainder, 2 centavos.
+	s := SolvePrincipal(ParcelTarget{Amount: 1_000, IncludeIOF: true}, 3,
+		utcDate(2024, 1, 5), utcDate(2024, 2, 5), defaultIOFConfig(), config.InstallmentConfig{})
+
+	if s.Principal != 2_969 {
+		t.Fatalf("expected principal 2969, got %d", s.Principal)
+	}
+	if got := s.Plan.Installments[0]; got.Principal != 991 || got.Amount != 998 {
+		t.Fatalf("expected first installment 991 + IOF = 998, got %+v", got)
+	}
+	if got := s.Plan.Installments[2]; got.Principal != 989 || got.Amount != 1_000 {
+		t.Fatalf("expected last installment 989 + IOF = 1000, got %+v", got)
+	}
+}
+
+func TestSolvePrincipal_FinancedIOF(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	firstDueDate := utcDate(2024, 2, 5)
+	tests := []struct {
+		name    string
+		instCfg config.InstallmentConfig
+		want    domain.Money
+	}{
+		{"sem juros", config.InstallmentConfig{FinanceIOF: true}, 98_150},
+		{"price", config.InstallmentConfig{MonthlyRate: 19_900, FinanceIOF: true}, 88_179},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			target := ParcelTarget{Amount: 9_990}
+			s := SolvePrincipal(target, 10, purchaseDate, firstDueDate, defaultIOFConfig(), tc.instCfg)
+			if s.Principal != tc.want || s.Parcel > target.Amount {
+				t.Fatalf("expected principal %d within the target, got %d (parcel %d)", tc.want, s.Principal, s.Parcel)
+			}
+			// The gross-up rounding is not monotonic: no larger amount nearby fits.
+			for amount := s.Principal + 1; amount <= s.Principal+500; amount++ {
+				plan := CalculateInstallmentPlan(amount, 10, purchaseDate, firstDueDate, defaultIOFConfig(), tc.instCfg)
+				if LargestParcel(plan, false) <= target.Amount {
+					t.Fatalf("principal %d also fits", amount)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveInstallments(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	firstDueDate := utcDate(2024, 2, 5)
+	price := config.InstallmentConfig{MonthlyRate: 19_900}
+	target := ParcelTarget{Amount: 20_000, IncludeIOF: true}
+
+	s, ok := SolveInstallments(100_000, target, 12, purchaseDate, firstDueDate, defaultIOFConfig(), price)
+	if !ok {
+		t.Fatalf("expected a plan to fit")
+	}
+	if s.Installments != 6 || s.Parcel != 18_174 || s.Principal != 100_000 {
+		t.Fatalf("expected 6x with parcel 18174, got %dx with parcel %d", s.Installments, s.Parcel)
+	}
+
+	if _, ok := SolveInstallments(100_000, ParcelTarget{Amount: 5_000}, 12, purchaseDate, firstDueDate, defaultIOFConfig(), price); ok {
+		t.Fatalf("expected no plan up to 12x to fit R$ 50,00")
+	}
+}
+
+func TestSolveDownPayment(t *testing.T) {
+	purchaseDate := utcDate(2024, 1, 5)
+	firstDueDate := utcDate(2024, 2, 5)
+	price := config.InstallmentConfig{MonthlyRate: 19_900}
+	target := ParcelTarget{Amount: 20_000, IncludeIOF: true}
+
+	s := SolveDownPayment(300_000, target, 10, purchaseDate, firstDueDate, defaultIOFConfig(), price)
+	if s.DownPayment != 125_184 || s.Principal != 174_816 || s.Parcel != 20_000 {
+		t.Fatalf("expected entrada 125184 and principal 174816, got %d and %d (parcel %d)", s.DownPayment, s.Principal, s.Parcel)
+	}
+
+	s = SolveDownPayment(100_000, target, 10, purchaseDate, firstDueDate, defaultIOFConfig(), price)
+	if s.DownPayment != 0 || s.Principal != 100_000 {
+		t.Fatalf("expected no entrada, got %d", s.DownPayment)
+	}
+}
@@ -42,3 +42,12 @@ func defaultRotativeRulesConfig() config.RotativeRulesConfig {
 func utcDate(y int, m time.Month, d int) time.Time {
 	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
 }
+
+func defaultMinimumPaymentConfig() config.MinimumPaymentConfig {
+	return config.MinimumPaymentConfig{
+		Rate:                150_000,
+		Floor:               5_000,
+		IncludeInstallments: true,
+		IncludeCharges:      true,
+	}
+}
@@ -9,5 +9,5 @@ import (
 //
 // Input validation (non-negative amount) is the caller's responsibility.
 func CalculateInternationalIOF(amount domain.Money, cfg config.InternationalIOFConfig) domain.Money {
-	return mulRate(amount, cfg.Rate)
+	return mulRate(amount, cfg.Rate, cfg.Rounding)
 }
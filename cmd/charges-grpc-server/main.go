@@ -0,0 +1,49 @@
+// Command charges-grpc-server serves the charges.v1 gRPC services.
+// Rates come from the environment (see config.LoadFromEnv).
+//
+//	go run ./cmd/charges-grpc-server -addr :9090
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/grpcapi"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	flag.Parse()
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	grpcapi.Register(srv, cfg)
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		srv.GracefulStop()
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+	"github.com/thiagozs/go-calc-charges-engine/httpapi"
+	"github.com/thiagozs/go-calc-charges-engine/service"
+)
+
+// errUsage signals a flag error already reported by the flag package.
+var errUsage = errors.New("usage")
+
+type command func(cfg config.EngineConfig, args []string, stdout, stderr io.Writer) error
+
+var commands = map[string]command{
+	"rotative":     runRotative,
+	"installments": runInstallments,
+	"iof":          runIOF,
+	"pay":          runPay,
+}
+
+// payScenario is the input of the pay command: a rotative request plus the payment.
+type payScenario struct {
+	httpapi.RotativeRequest
+	Payment domain.Money `json:"payment"`
+}
+
+// payOutput is the JSON output of the pay command.
+type payOutput struct {
+	Rotative httpapi.RotativeResponse `json:"rotative"`
+	Payment  httpapi.PaymentResponse  `json:"payment"`
+}
+
+// commonFlags are shared by every command.
+type commonFlags struct {
+	file   string
+	output string
+}
+
+func newFlagSet(name string, stderr io.Writer, common *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&common.file, "f", "", "JSON scenario file (same schema as the HTTP API request)")
+	fs.StringVar(&common.output, "o", "table", "output format: table, json or explain")
+	return fs
+}
+
+func parse(fs *flag.FlagSet, args []string, common *commonFlags) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	switch common.output {
+	case "table", "json", "explain":
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q", common.output)
+	}
+}
+
+func runRotative(cfg config.EngineConfig, args []string, stdout, stderr io.Writer) error {
+	var common commonFlags
+	var req httpapi.RotativeRequest
+	fs := newFlagSet("rotative", stderr, &common)
+	fs.StringVar(&req.AccountID, "account", "", "account ID")
+	fs.Var(moneyFlag{&req.Principal}, "principal", "balance in rotative (centavos)")
+	fs.Var(dateFlag{&req.StartDate}, "start", "date the balance entered rotative (YYYY-MM-DD)")
+	fs.Var(dateFlag{&req.CalcDate}, "at", "calculation date (YYYY-MM-DD)")
+	if err := parse(fs, args, &common); err != nil {
+		return err
+	}
+	if err := loadScenario(common.file, &req); err != nil {
+		return err
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	svc := service.NewRotativeService(cfg)
+	balance := req.Balance()
+	result := svc.Calculate(balance, req.CalcDate.Time)
+
+	switch common.output {
+	case "json":
+		return writeJSON(stdout, httpapi.NewRotativeResponse(result))
+	case "explain":
+		exp := calc.ExplainRotative(balance, req.CalcDate.Time, svc.IOFConfig, svc.InterestConfig, svc.LateFeeConfig, svc.LateInterestConfig, svc.RulesConfig)
+		_, err := fmt.Fprint(stdout, exp.Text())
+		return err
+	}
+	return printRotative(stdout, result)
+}
+
+func runInstallments(cfg config.EngineConfig, args []string, stdout, stderr io.Writer) error {
+	var common commonFlags
+	var req httpapi.InstallmentRequest
+	fs := newFlagSet("installments", stderr, &common)
+	fs.StringVar(&req.AccountID, "account", "", "account ID")
+	fs.Var(moneyFlag{&req.Amount}, "amount", "purchase amount (centavos)")
+	fs.IntVar(&req.Installments, "n", 1, "number of installments")
+	fs.Var(dateFlag{&req.PurchaseDate}, "purchase", "purchase date (YYYY-MM-DD)")
+	fs.Var(dateFlag{&req.FirstDueDate}, "first-due", "first installment due date (YYYY-MM-DD)")
+	if err := parse(fs, args, &common); err != nil {
+		return err
+	}
+	if err := loadScenario(common.file, &req); err != nil {
+		return err
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	svc := service.NewInstallmentService(cfg)
+	plan := svc.CalculateForAccount(req.AccountID, req.Amount, req.Installments, req.PurchaseDate.Time, req.FirstDueDate.Time)
+
+	switch common.output {
+	case "json":
+		return writeJSON(stdout, httpapi.NewInstallmentPlanResponse(plan))
+	case "explain":
+		exp := calc.ExplainInstallmentPlan(req.Amount, req.Installments, req.PurchaseDate.Time, req.FirstDueDate.Time, svc.IOFConfig, svc.InstallmentConfig)
+		_, err := fmt.Fprint(stdout, exp.Text())
+		return err
+	}
+	return printPlan(stdout, plan)
+}
+
+func runIOF(cfg config.EngineConfig, args []string, stdout, stderr io.Writer) error {
+	var common commonFlags
+	var req httpapi.IOFRequest
+	fs := newFlagSet("iof", stderr, &common)
+	fs.Var(moneyFlag{&req.Amount}, "amount", "principal or purchase amount (centavos)")
+	fs.IntVar(&req.Days, "days", 0, "days of credit IOF")
+	fs.BoolVar(&req.International, "international", false, "international purchase IOF")
+	if err := parse(fs, args, &common); err != nil {
+		return err
+	}
+	if err := loadScenario(common.file, &req); err != nil {
+		return err
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	svc := service.NewIOFService(cfg)
+	var resp httpapi.IOFResponse
+	if req.International {
+		resp.IOF = svc.CalculateInternational(req.Amount)
+	} else {
+		resp.IOF = svc.Calculate(req.Amount, req.Days)
+	}
+
+	switch common.output {
+	case "json":
+		return writeJSON(stdout, resp)
+	case "explain":
+		if req.International {
+			return errors.New("explain is not available for international IOF")
+		}
+		_, err := fmt.Fprint(stdout, calc.ExplainIOF(req.Amount, req.Days, svc.IOFConfig).Text())
+		return err
+	}
+	return printRows(stdout, [][2]string{{"IOF", formatMoney(resp.IOF)}})
+}
+
+func runPay(cfg config.EngineConfig, args []string, stdout, stderr io.Writer) error {
+	var common commonFlags
+	var req payScenario
+	fs := newFlagSet("pay", stderr, &common)
+	fs.StringVar(&req.AccountID, "account", "", "account ID")
+	fs.Var(moneyFlag{&req.Principal}, "principal", "balance in rotative (centavos)")
+	fs.Var(dateFlag{&req.StartDate}, "start", "date the balance entered rotative (YYYY-MM-DD)")
+	fs.Var(dateFlag{&req.CalcDate}, "at", "payment date (YYYY-MM-DD)")
+	fs.Var(moneyFlag{&req.Payment}, "payment", "payment amount (centavos)")
+	if err := parse(fs, args, &common); err != nil {
+		return err
+	}
+	if err := loadScenario(common.file, &req); err != nil {
+		return err
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if req.Payment < 0 {
+		return errors.New("payment must not be negative")
+	}
+
+	svc := service.NewRotativeService(cfg)
+	result := svc.Calculate(req.Balance(), req.CalcDate.Time)
+	applied := svc.ApplyPayment(result, req.Payment)
+
+	switch common.output {
+	case "json":
+		return writeJSON(stdout, payOutput{
+			Rotative: httpapi.NewRotativeResponse(result),
+			Payment:  httpapi.NewPaymentResponse(applied),
+		})
+	case "explain":
+		return errors.New("explain is not available for pay; use rotative -o explain")
+	}
+	if err := printRotative(stdout, result); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout)
+	return printPayment(stdout, req.Payment, applied)
+}
+
+// loadScenario decodes the scenario file into v. Values already set by flags
+// are overwritten by the fields present in the file.
+func loadScenario(path string, v any) error {
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("scenario %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// moneyFlag parses an integer amount in centavos.
+type moneyFlag struct{ m *domain.Money }
+
+func (f moneyFlag) String() string {
+	if f.m == nil {
+		return "0"
+	}
+	return fmt.Sprint(int64(*f.m))
+}
+
+func (f moneyFlag) Set(s string) error {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q: expected integer centavos", s)
+	}
+	*f.m = domain.Money(v)
+	return nil
+}
+
+// dateFlag parses an ISO date.
+type dateFlag struct{ d *httpapi.Date }
+
+func (f dateFlag) String() string {
+	if f.d == nil || f.d.IsZero() {
+		return ""
+	}
+	return f.d.Format(time.DateOnly)
+}
+
+func (f dateFlag) Set(s string) error {
+	t, err := time.ParseInLocation(time.DateOnly, s, time.UTC)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", s)
+	}
+	f.d.Time = t
+	return nil
+}
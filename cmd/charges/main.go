@@ -0,0 +1,66 @@
+// Command charges simulates the engine calculations from the command line.
+// Rates come from the environment (see config.LoadFromEnv); inputs come from
+// flags or from a JSON scenario file using the same schema as the HTTP API.
+//
+//	charges rotative -principal 100000 -start 2024-01-01 -at 2024-01-31
+//	charges installments -amount 100000 -n 12 -purchase 2024-01-05 -first-due 2024-02-10
+//	charges iof -amount 100000 -days 30
+//	charges pay -principal 100000 -start 2024-01-01 -at 2024-01-31 -payment 40000
+//	charges rotative -f scenario.json -o json
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+)
+
+const usage = `usage: charges <command> [flags]
+
+commands:
+  rotative      rotative charges for a balance at a date
+  installments  installment plan for a purchase
+  iof           credit IOF or international purchase IOF
+  pay           rotative charges followed by a payment
+
+common flags:
+  -f file       read the input from a JSON scenario file instead of flags
+  -o format     output format: table (default), json or explain
+
+run "charges <command> -h" for the command flags.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the CLI and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		fmt.Fprintf(stderr, "load config: %v\n", err)
+		return 1
+	}
+
+	if err := cmd(cfg, args[1:], stdout, stderr); err != nil {
+		if err == errUsage {
+			return 2
+		}
+		fmt.Fprintf(stderr, "charges %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/httpapi"
+)
+
+func TestRun_RotativeFlagsTable(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"rotative", "-principal", "100000", "-start", "2024-01-01", "-at", "2024-01-31"}, &stdout, &stderr)
+
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "R$ 1.156,26") {
+		t.Fatalf("expected total R$ 1.156,26 in output, got:\n%s", stdout.String())
+	}
+}
+
+func TestRun_InstallmentsScenarioJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	scenario := `{"amount":100000,"installments":10,"purchase_date":"2024-01-15","first_due_date":"2024-02-10"}`
+	if err := os.WriteFile(path, []byte(scenario), 0o600); err != nil {
+		t.Fatalf("write scenario: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"installments", "-f", path, "-o", "json"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+
+	var plan httpapi.InstallmentPlanResponse
+	if err := json.Unmarshal(stdout.Bytes(), &plan); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(plan.Installments) != 10 || plan.TotalIOF != 1_712 {
+		t.Fatalf("unexpected plan: %d installments, IOF %d", len(plan.Installments), plan.TotalIOF)
+	}
+}
+
+func TestRun_PayJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"pay", "-principal", "100000", "-start", "2024-01-01", "-at", "2024-01-31", "-payment", "40000", "-o", "json"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+
+	var out payOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if out.Payment.Remaining != out.Rotative.Total-40_000 {
+		t.Fatalf("expected remaining %d, got %d", out.Rotative.Total-40_000, out.Payment.Remaining)
+	}
+}
+
+func TestRun_IOFExplain(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"iof", "-amount", "100000", "-days", "30", "-o", "explain"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Resultado: R$ 6,26") {
+		t.Fatalf("expected explanation result, got:\n%s", stdout.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"no command", nil, 2},
+		{"unknown command", []string{"nope"}, 2},
+		{"bad flag", []string{"iof", "-nope"}, 2},
+		{"bad date", []string{"rotative", "-start", "01/01/2024"}, 2},
+		{"decimal amount", []string{"iof", "-amount", "1000.50"}, 2},
+		{"amount with trailing text", []string{"iof", "-amount", "12abc"}, 2},
+		{"missing dates", []string{"rotative", "-principal", "100"}, 1},
+		{"bad output", []string{"iof", "-amount", "1", "-o", "xml"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, &stdout, &stderr); code != tt.code {
+				t.Fatalf("expected exit %d, got %d (stderr: %s)", tt.code, code, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func printRotative(w io.Writer, r calc.RotativeResult) error {
+	rows := [][2]string{
+		{"Dias em atraso", fmt.Sprintf("%d (cobrados %d)", r.Days, r.ChargedDays)},
+		{"Principal", formatMoney(r.Principal)},
+		{"IOF", formatMoney(r.IOF)},
+		{"Juros rotativo", formatMoney(r.Interest)},
+		{"Juros de mora", formatMoney(r.LateInterest)},
+		{"Multa", formatMoney(r.LateFee)},
+		{"Total encargos", formatMoney(r.Charges)},
+		{"Total", formatMoney(r.Total)},
+		{"Teto aplicado", yesNo(r.ChargeCapped)},
+	}
+	if r.Override != nil {
+		rows = append(rows, [2]string{"Taxa negociada", r.Override.ReasonCode})
+	}
+	return printRows(w, rows)
+}
+
+func printPayment(w io.Writer, payment domain.Money, a calc.AmortizationResult) error {
+	return printRows(w, [][2]string{
+		{"Pagamento", formatMoney(payment)},
+		{"Pago IOF", formatMoney(a.PaidIOF)},
+		{"Pago juros", formatMoney(a.PaidInterest)},
+		{"Pago juros de mora", formatMoney(a.PaidLateInterest)},
+		{"Pago multa", formatMoney(a.PaidLateFee)},
+		{"Pago principal", formatMoney(a.PaidPrincipal)},
+		{"Em aberto", formatMoney(a.Remaining)},
+	})
+}
+
+func printPlan(w io.Writer, plan domain.InstallmentPlan) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Parcela\tVencimento\tPrincipal\tJuros\tIOF\tTotal\t")
+	for _, inst := range plan.Installments {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t\n",
+			inst.Number,
+			inst.DueDate.Format(time.DateOnly),
+			formatMoney(inst.Principal),
+			formatMoney(inst.Interest),
+			formatMoney(inst.IOF),
+			formatMoney(inst.Amount),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	rows := [][2]string{
+		{"Total compra", formatMoney(plan.TotalAmount)},
+		{"Total juros", formatMoney(plan.TotalInterest)},
+		{"Total IOF", formatMoney(plan.TotalIOF)},
+		{"Total com juros + IOF", formatMoney(plan.TotalWithIOF)},
+	}
+	if plan.GraceInterest > 0 {
+		rows = append(rows, [2]string{"Juros de carencia", formatMoney(plan.GraceInterest)})
+	}
+	if plan.GraceInterest < 0 {
+		rows = append(rows, [2]string{"Desconto 1o periodo", formatMoney(-plan.GraceInterest)})
+	}
+	if plan.IOFFinanced {
+		rows = append(rows, [2]string{"IOF financiado", "sim"})
+	}
+	if plan.Override != nil {
+		rows = append(rows, [2]string{"Taxa negociada", plan.Override.ReasonCode})
+	}
+	return printRows(w, rows)
+}
+
+func printRows(w io.Writer, rows [][2]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%s\n", r[0], r[1])
+	}
+	return tw.Flush()
+}
+
+// formatMoney formats centavos as "R$ 1.234,56" without going through float64.
+func formatMoney(v domain.Money) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	s := fmt.Sprintf("%d", int64(v)/100)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "." + s[i:]
+	}
+	return fmt.Sprintf("%sR$ %s,%02d", sign, s, int64(v)%100)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "sim"
+	}
+	return "nao"
+}
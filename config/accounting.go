@@ -0,0 +1,25 @@
+package config
+
+// ChartOfAccounts maps the engine amounts to COSIF accounts for journal
+// entries. The defaults sit in the usual COSIF groups (1.1 disponibilidades,
+// 1.6 operacoes de credito, 4.9 outras obrigacoes, 7.1 rendas de operacoes de
+// credito, 7.1.9 outras rendas operacionais) and must be confirmed against the
+// institution's own chart.
+type ChartOfAccounts struct {
+	// Cash receives customer payments.
+	Cash string `env:"COSIF_CASH" envDefault:"1.1.2.10.00"`
+	// Receivables holds what cardholders owe.
+	Receivables string `env:"COSIF_RECEIVABLES" envDefault:"1.6.1.20.00"`
+	// UnearnedInterest holds installment interest not yet earned (rendas a apropriar).
+	UnearnedInterest string `env:"COSIF_UNEARNED_INTEREST" envDefault:"1.6.1.99.00"`
+	// MerchantPayable holds purchase amounts owed to merchants and acquirers.
+	MerchantPayable string `env:"COSIF_MERCHANT_PAYABLE" envDefault:"4.9.9.92.00"`
+	// IOFPayable holds IOF collected and owed to the Receita Federal.
+	IOFPayable string `env:"COSIF_IOF_PAYABLE" envDefault:"4.9.4.20.00"`
+	// InterestRevenue receives rotative and installment interest.
+	InterestRevenue string `env:"COSIF_INTEREST_REVENUE" envDefault:"7.1.1.05.00"`
+	// LateInterestRevenue receives juros de mora.
+	LateInterestRevenue string `env:"COSIF_LATE_INTEREST_REVENUE" envDefault:"7.1.1.05.00"`
+	// LateFeeRevenue receives the late fee (multa).
+	LateFeeRevenue string `env:"COSIF_LATE_FEE_REVENUE" envDefault:"7.1.9.99.00"`
+}
@@ -3,13 +3,18 @@ package config
 import "github.com/caarlos0/env/v11"
 
 type EngineConfig struct {
-	IOF              IOFConfig
-	Interest         InterestConfig
-	LateFee          LateFeeConfig
-	LateInterest     LateInterestConfig
-	Rules            RotativeRulesConfig
-	InternationalIOF InternationalIOFConfig
-	Installment      InstallmentConfig
+	IOF               IOFConfig
+	Interest          InterestConfig
+	LateFee           LateFeeConfig
+	LateInterest      LateInterestConfig
+	Rules             RotativeRulesConfig
+	InternationalIOF  InternationalIOFConfig
+	Installment       InstallmentConfig
+	InstallmentPolicy InstallmentPolicyConfig
+	MinimumPayment    MinimumPaymentConfig
+	Payoff            PayoffConfig
+	Renegotiation     RenegotiationConfig
+	Chart             ChartOfAccounts
 }
 
 func LoadFromEnv() (EngineConfig, error) {
@@ -19,3 +24,13 @@ func LoadFromEnv() (EngineConfig, error) {
 	}
 	return cfg, nil
 }
+
+// Defaults returns the config with every envDefault value, ignoring the
+// process environment.
+func Defaults() (EngineConfig, error) {
+	var cfg EngineConfig
+	if err := env.ParseWithOptions(&cfg, env.Options{Environment: map[string]string{}}); err != nil {
+		return EngineConfig{}, err
+	}
+	return cfg, nil
+}
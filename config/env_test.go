@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestLoadFromEnv_Funding(t *testing.T) {
+	t.Setenv("INSTALLMENT_FUNDING", "merchant")
+	cfg, err := LoadFromEnv()
+	if err != nil || cfg.Installment.Funding != domain.FundingMerchant {
+		t.Fatalf("expected merchant funding, got %q (%v)", cfg.Installment.Funding, err)
+	}
+
+	t.Setenv("INSTALLMENT_FUNDING", "lojista")
+	if _, err := LoadFromEnv(); err == nil || !strings.Contains(err.Error(), `unknown installment funding "lojista"`) {
+		t.Fatalf("expected unknown funding error, got %v", err)
+	}
+}
+
+func TestLoadFromEnv_RoundingMode(t *testing.T) {
+	t.Setenv("ROUNDING_MODE", "half_even")
+	cfg, err := LoadFromEnv()
+	if err != nil || cfg.Interest.Rounding != domain.RoundHalfEven || cfg.Installment.Rounding != domain.RoundHalfEven {
+		t.Fatalf("expected half_even everywhere, got %q / %q (%v)", cfg.Interest.Rounding, cfg.Installment.Rounding, err)
+	}
+
+	t.Setenv("ROUNDING_MODE", "bankers")
+	if _, err := LoadFromEnv(); err == nil || !strings.Contains(err.Error(), `unknown rounding mode "bankers"`) {
+		t.Fatalf("expected unknown rounding mode error, got %v", err)
+	}
+
+	t.Setenv("ROUNDING_MODE", "half_up")
+	t.Setenv("INSTALLMENT_REMAINDER", "middle")
+	if _, err := LoadFromEnv(); err == nil || !strings.Contains(err.Error(), `unknown remainder placement "middle"`) {
+		t.Fatalf("expected unknown remainder placement error, got %v", err)
+	}
+}
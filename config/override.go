@@ -0,0 +1,105 @@
+package config
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// RateOverride holds rates negotiated for a single account (e.g. by collections).
+// Nil rate fields keep the product profile value. A zero EffectiveUntil means
+// the override has no end date.
+type RateOverride struct {
+	AccountID      string
+	ReasonCode     string
+	EffectiveFrom  time.Time
+	EffectiveUntil time.Time
+
+	RotativeMonthlyRate     *domain.Rate
+	LateFeeRate             *domain.Rate
+	LateInterestMonthlyRate *domain.Rate
+	InstallmentMonthlyRate  *domain.Rate
+}
+
+// ActiveAt reports whether the override is in effect at t.
+// The window includes EffectiveFrom and excludes EffectiveUntil.
+func (o RateOverride) ActiveAt(t time.Time) bool {
+	if t.Before(o.EffectiveFrom) {
+		return false
+	}
+	if !o.EffectiveUntil.IsZero() && !t.Before(o.EffectiveUntil) {
+		return false
+	}
+	return true
+}
+
+// ApplyRotative merges the override into the rotative product configs.
+func (o RateOverride) ApplyRotative(
+	intCfg InterestConfig,
+	lateFeeCfg LateFeeConfig,
+	lateInterestCfg LateInterestConfig,
+) (InterestConfig, LateFeeConfig, LateInterestConfig) {
+	if o.RotativeMonthlyRate != nil {
+		intCfg.MonthlyRate = *o.RotativeMonthlyRate
+	}
+	if o.LateFeeRate != nil {
+		lateFeeCfg.Rate = *o.LateFeeRate
+	}
+	if o.LateInterestMonthlyRate != nil {
+		lateInterestCfg.MonthlyRate = *o.LateInterestMonthlyRate
+	}
+	return intCfg, lateFeeCfg, lateInterestCfg
+}
+
+// ApplyInstallment merges the override into the installment product config.
+func (o RateOverride) ApplyInstallment(cfg InstallmentConfig) InstallmentConfig {
+	if o.InstallmentMonthlyRate != nil {
+		cfg.MonthlyRate = *o.InstallmentMonthlyRate
+	}
+	return cfg
+}
+
+// Ref returns the identification of the override kept in calculation results.
+func (o RateOverride) Ref() *domain.OverrideRef {
+	return &domain.OverrideRef{
+		AccountID:      o.AccountID,
+		ReasonCode:     o.ReasonCode,
+		EffectiveFrom:  o.EffectiveFrom,
+		EffectiveUntil: o.EffectiveUntil,
+	}
+}
+
+// OverrideProvider resolves the rate override in effect for an account at a date.
+type OverrideProvider interface {
+	Resolve(accountID string, at time.Time) (RateOverride, bool)
+}
+
+// RateOverrides is an in-memory OverrideProvider keyed by account ID.
+type RateOverrides map[string][]RateOverride
+
+// NewRateOverrides indexes the given overrides by account ID.
+func NewRateOverrides(overrides ...RateOverride) RateOverrides {
+	r := make(RateOverrides)
+	for _, o := range overrides {
+		r[o.AccountID] = append(r[o.AccountID], o)
+	}
+	return r
+}
+
+// Resolve returns the active override for the account at the given date.
+// When several overrides are active, the one with the latest EffectiveFrom wins;
+// ties go to the one registered last.
+func (r RateOverrides) Resolve(accountID string, at time.Time) (RateOverride, bool) {
+	var found RateOverride
+	ok := false
+	for _, o := range r[accountID] {
+		if !o.ActiveAt(at) {
+			continue
+		}
+		if !ok || !o.EffectiveFrom.Before(found.EffectiveFrom) {
+			found = o
+			ok = true
+		}
+	}
+	return found, ok
+}
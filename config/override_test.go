@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func rate(r domain.Rate) *domain.Rate {
+	return &r
+}
+
+func TestRateOverride_ActiveAt(t *testing.T) {
+	o := RateOverride{EffectiveFrom: date(2024, 1, 1), EffectiveUntil: date(2024, 2, 1)}
+
+	if o.ActiveAt(date(2023, 12, 31)) {
+		t.Fatalf("override should not be active before EffectiveFrom")
+	}
+	if !o.ActiveAt(date(2024, 1, 1)) {
+		t.Fatalf("override should be active on EffectiveFrom")
+	}
+	if o.ActiveAt(date(2024, 2, 1)) {
+		t.Fatalf("override should not be active on EffectiveUntil")
+	}
+
+	openEnded := RateOverride{EffectiveFrom: date(2024, 1, 1)}
+	if !openEnded.ActiveAt(date(2030, 1, 1)) {
+		t.Fatalf("open-ended override should stay active")
+	}
+}
+
+func TestRateOverrides_ResolveLatestWins(t *testing.T) {
+	overrides := NewRateOverrides(
+		RateOverride{AccountID: "acc-1", ReasonCode: "ACORDO-1", EffectiveFrom: date(2024, 1, 1), RotativeMonthlyRate: rate(80_000)},
+		RateOverride{AccountID: "acc-1", ReasonCode: "ACORDO-2", EffectiveFrom: date(2024, 3, 1), RotativeMonthlyRate: rate(60_000)},
+		RateOverride{AccountID: "acc-2", ReasonCode: "OUTRA", EffectiveFrom: date(2024, 1, 1)},
+	)
+
+	o, ok := overrides.Resolve("acc-1", date(2024, 2, 15))
+	if !ok || o.ReasonCode != "ACORDO-1" {
+		t.Fatalf("expected ACORDO-1, got %q (ok=%v)", o.ReasonCode, ok)
+	}
+
+	o, ok = overrides.Resolve("acc-1", date(2024, 4, 1))
+	if !ok || o.ReasonCode != "ACORDO-2" {
+		t.Fatalf("expected ACORDO-2, got %q (ok=%v)", o.ReasonCode, ok)
+	}
+
+	if _, ok := overrides.Resolve("acc-3", date(2024, 4, 1)); ok {
+		t.Fatalf("expected no override for unknown account")
+	}
+}
+
+func TestRateOverride_ApplyKeepsUnsetRates(t *testing.T) {
+	o := RateOverride{RotativeMonthlyRate: rate(80_000)}
+
+	intCfg, lateFeeCfg, lateInterestCfg := o.ApplyRotative(
+		InterestConfig{MonthlyRate: 120_000},
+		LateFeeConfig{Rate: 20_000},
+		LateInterestConfig{MonthlyRate: 10_000},
+	)
+
+	if intCfg.MonthlyRate != 80_000 {
+		t.Fatalf("expected overridden rotative rate 80000, got %d", intCfg.MonthlyRate)
+	}
+	if lateFeeCfg.Rate != 20_000 {
+		t.Fatalf("expected product late fee 20000, got %d", lateFeeCfg.Rate)
+	}
+	if lateInterestCfg.MonthlyRate != 10_000 {
+		t.Fatalf("expected product late interest 10000, got %d", lateInterestCfg.MonthlyRate)
+	}
+
+	instCfg := o.ApplyInstallment(InstallmentConfig{MonthlyRate: 19_900})
+	if instCfg.MonthlyRate != 19_900 {
+		t.Fatalf("expected product installment rate 19900, got %d", instCfg.MonthlyRate)
+	}
+}
@@ -6,29 +6,99 @@ type IOFConfig struct {
 	DailyRate      domain.Rate `env:"IOF_DAILY_RATE" envDefault:"82"`
 	AdditionalRate domain.Rate `env:"IOF_ADDITIONAL_RATE" envDefault:"3800"`
 	MaxAnnualRate  domain.Rate `env:"IOF_MAX_ANNUAL_RATE" envDefault:"40800"`
+	// Rounding applies to every amount computed from these rates. ROUNDING_MODE
+	// sets the same mode on every config; the zero value rounds half-up.
+	Rounding domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
 }
 
 type InterestConfig struct {
-	MonthlyRate domain.Rate `env:"ROTATIVE_MONTHLY_RATE" envDefault:"120000"`
+	MonthlyRate domain.Rate         `env:"ROTATIVE_MONTHLY_RATE" envDefault:"120000"`
+	Rounding    domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
 }
 
 type LateFeeConfig struct {
-	Rate domain.Rate `env:"LATE_FEE_RATE" envDefault:"20000"`
+	Rate     domain.Rate         `env:"LATE_FEE_RATE" envDefault:"20000"`
+	Rounding domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
 }
 
 type LateInterestConfig struct {
-	MonthlyRate domain.Rate `env:"LATE_INTEREST_MONTHLY_RATE" envDefault:"10000"`
+	MonthlyRate domain.Rate         `env:"LATE_INTEREST_MONTHLY_RATE" envDefault:"10000"`
+	Rounding    domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
 }
 
 type RotativeRulesConfig struct {
-	MaxDays       int         `env:"ROTATIVE_MAX_DAYS" envDefault:"30"`
-	MaxChargeRate domain.Rate `env:"ROTATIVE_MAX_CHARGE_RATE" envDefault:"1000000"`
+	MaxDays       int                 `env:"ROTATIVE_MAX_DAYS" envDefault:"30"`
+	MaxChargeRate domain.Rate         `env:"ROTATIVE_MAX_CHARGE_RATE" envDefault:"1000000"`
+	Rounding      domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
 }
 
 type InternationalIOFConfig struct {
-	Rate domain.Rate `env:"INTERNATIONAL_IOF_RATE" envDefault:"35000"`
+	Rate     domain.Rate         `env:"INTERNATIONAL_IOF_RATE" envDefault:"35000"`
+	Rounding domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
 }
 
 type InstallmentConfig struct {
 	MonthlyRate domain.Rate `env:"INSTALLMENT_MONTHLY_RATE" envDefault:"0"`
+	// Funding selects parcelado emissor ("issuer") or lojista ("merchant").
+	// Merchant-funded plans ignore MonthlyRate and carry no IOF.
+	Funding domain.InstallmentFunding `env:"INSTALLMENT_FUNDING" envDefault:"issuer"`
+	// GracePeriod charges the days between purchase and first due date beyond
+	// one regular period (carencia), capitalized into the PMT, and discounts a
+	// shorter first period. When false the first period is always treated as
+	// exactly one month.
+	GracePeriod bool `env:"INSTALLMENT_GRACE_PERIOD" envDefault:"false"`
+	// Rounding applies to the PMT, the parcel interest and the IOF.
+	Rounding domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
+	// Remainder selects the parcel that takes the centavos left over by the
+	// equal split of interest-free and merchant-funded plans.
+	Remainder domain.RemainderPlacement `env:"INSTALLMENT_REMAINDER" envDefault:"first"`
+	// FinanceIOF finances the IOF inside the plan (IOF financiado): it is
+	// added to the financed amount and parcels are equal, instead of each
+	// parcel carrying its own IOF on top.
+	FinanceIOF bool `env:"INSTALLMENT_FINANCE_IOF" envDefault:"false"`
+}
+
+// MinimumPaymentConfig is the invoice minimum payment rule: Rate of the
+// revolving balance plus, when included, the installment parcels and charges
+// billed in full, never below Floor nor above the outstanding balance.
+type MinimumPaymentConfig struct {
+	Rate                domain.Rate         `env:"MIN_PAYMENT_RATE" envDefault:"150000"`
+	Floor               domain.Money        `env:"MIN_PAYMENT_FLOOR" envDefault:"5000"`
+	IncludeInstallments bool                `env:"MIN_PAYMENT_INCLUDE_INSTALLMENTS" envDefault:"true"`
+	IncludeCharges      bool                `env:"MIN_PAYMENT_INCLUDE_CHARGES" envDefault:"true"`
+	Rounding            domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
+}
+
+// PayoffConfig configures payoff quotes: a quote is honored for ValidityDays
+// after its date.
+type PayoffConfig struct {
+	ValidityDays int                 `env:"PAYOFF_VALIDITY_DAYS" envDefault:"1"`
+	Rounding     domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
+}
+
+// RenegotiationConfig is the debt agreement (acordo) policy: the share of each
+// debt bucket waived, the minimum entrada as a share of the renegotiated debt,
+// and the rate and length of the new plan. IOF is never discounted.
+type RenegotiationConfig struct {
+	PrincipalDiscount    domain.Rate `env:"RENEGOTIATION_PRINCIPAL_DISCOUNT" envDefault:"0"`
+	InterestDiscount     domain.Rate `env:"RENEGOTIATION_INTEREST_DISCOUNT" envDefault:"0"`
+	LateInterestDiscount domain.Rate `env:"RENEGOTIATION_LATE_INTEREST_DISCOUNT" envDefault:"0"`
+	LateFeeDiscount      domain.Rate `env:"RENEGOTIATION_LATE_FEE_DISCOUNT" envDefault:"0"`
+	MinDownPaymentRate   domain.Rate `env:"RENEGOTIATION_MIN_DOWN_PAYMENT_RATE" envDefault:"100000"`
+	MonthlyRate          domain.Rate `env:"RENEGOTIATION_MONTHLY_RATE" envDefault:"49900"`
+	// MaxInstallments bounds the new plan; 0 means no limit.
+	MaxInstallments int  `env:"RENEGOTIATION_MAX_INSTALLMENTS" envDefault:"24"`
+	FinanceIOF      bool `env:"RENEGOTIATION_FINANCE_IOF" envDefault:"false"`
+	// Rounding applies to the discounts, the minimum entrada and the new plan.
+	Rounding domain.RoundingMode `env:"ROUNDING_MODE" envDefault:"half_up"`
+}
+
+// InstallmentPolicyConfig describes the installment offers of a product at checkout:
+// 1x..InterestFreeUpTo are sem juros, the rest are charged MonthlyRate.
+// Offers whose parcel is below MinInstallmentAmount are not shown (1x always is).
+type InstallmentPolicyConfig struct {
+	MaxInstallments      int          `env:"INSTALLMENT_POLICY_MAX" envDefault:"12"`
+	InterestFreeUpTo     int          `env:"INSTALLMENT_POLICY_INTEREST_FREE_UP_TO" envDefault:"1"`
+	MonthlyRate          domain.Rate  `env:"INSTALLMENT_POLICY_MONTHLY_RATE" envDefault:"19900"`
+	MinInstallmentAmount domain.Money `env:"INSTALLMENT_POLICY_MIN_AMOUNT" envDefault:"500"`
 }
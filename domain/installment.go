@@ -1,15 +1,56 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// InstallmentFunding identifies who funds an installment plan.
+type InstallmentFunding string
+
+const (
+	// FundingIssuer is parcelado emissor: the issuer finances the plan and the
+	// cardholder pays interest (if any) and IOF. The zero value means issuer.
+	FundingIssuer InstallmentFunding = "issuer"
+	// FundingMerchant is parcelado lojista: the merchant finances the plan and
+	// the cardholder pays neither interest nor IOF.
+	FundingMerchant InstallmentFunding = "merchant"
+)
+
+// UnmarshalText accepts FundingIssuer, FundingMerchant and the empty value,
+// so an unknown funding in the environment or in JSON fails to load instead
+// of being priced as issuer.
+func (f *InstallmentFunding) UnmarshalText(text []byte) error {
+	switch v := InstallmentFunding(text); v {
+	case "", FundingIssuer, FundingMerchant:
+		*f = v
+		return nil
+	}
+	return fmt.Errorf("domain: unknown installment funding %q, want %q or %q", text, FundingIssuer, FundingMerchant)
+}
 
 // InstallmentPlan represents a complete installment plan for a credit card purchase.
-// The caller (ledger) should persist this struct for audit trail purposes.
+// The caller (ledger) should persist this struct for audit trail purposes,
+// sealed with audit.SealInstallment so inputs and config are kept with it.
 type InstallmentPlan struct {
 	TotalAmount   Money
 	TotalIOF      Money
 	TotalInterest Money
 	TotalWithIOF  Money
-	Installments  []Installment
+	// GraceInterest is the interest of a grace period (carencia) capitalized
+	// into the PMT, or the discount of a first period shorter than 30 days
+	// when negative. It is already included in TotalInterest.
+	GraceInterest Money
+	// RoundingResidue is the rounded minus the exact charges of the plan, in
+	// 1/ResidueDenominator of a centavo.
+	RoundingResidue int64
+	// IOFFinanced reports that TotalIOF was financed inside the parcels (IOF
+	// financiado) instead of added on top of each one.
+	IOFFinanced  bool
+	Funding      InstallmentFunding
+	Installments []Installment
+	// Override identifies the negotiated rates used, if any.
+	Override *OverrideRef
 }
 
 // Installment represents a single installment in a plan.
@@ -20,4 +61,5 @@ type Installment struct {
 	Interest  Money
 	IOF       Money
 	Amount    Money
+	Funding   InstallmentFunding
 }
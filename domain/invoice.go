@@ -3,9 +3,48 @@ package domain
 import "time"
 
 type Invoice struct {
-    ID          string
-    ClosingDate time.Time
-    DueDate     time.Time
-    TotalAmount Money
-    PaidAmount  Money
+	ID          string
+	ClosingDate time.Time
+	DueDate     time.Time
+	TotalAmount Money
+	PaidAmount  Money
+	// Status is driven by calc.ApplyInvoiceEvent; empty means open.
+	Status InvoiceStatus
+	// MinimumPayment is the least amount that avoids default, set by
+	// calc.WithMinimumPayment.
+	MinimumPayment Money
+	Lines          []InvoiceLine
+}
+
+// InvoiceStatus is the lifecycle state of an invoice.
+type InvoiceStatus string
+
+const (
+	InvoiceOpen          InvoiceStatus = "open"           // accepting purchases
+	InvoiceClosed        InvoiceStatus = "closed"         // closed, awaiting payment until due date
+	InvoicePaid          InvoiceStatus = "paid"           // fully paid
+	InvoicePartiallyPaid InvoiceStatus = "partially_paid" // paid in part, not yet due
+	InvoiceOverdue       InvoiceStatus = "overdue"        // past due without the minimum payment
+	InvoiceInRotative    InvoiceStatus = "in_rotative"    // past due with the minimum paid; balance revolves
+	InvoiceInstalled     InvoiceStatus = "installed"      // balance moved to an installment plan
+	InvoiceWrittenOff    InvoiceStatus = "written_off"    // balance written off as loss
+)
+
+// InvoiceLineKind classifies an invoice line for presentation.
+type InvoiceLineKind string
+
+const (
+	InvoiceLineInstallment         InvoiceLineKind = "installment"
+	InvoiceLineInstallmentInterest InvoiceLineKind = "installment_interest"
+	InvoiceLineIOF                 InvoiceLineKind = "iof"
+	InvoiceLinePurchase            InvoiceLineKind = "purchase"
+	// InvoiceLineCharge is a rotative charge: interest, late fee or late interest.
+	InvoiceLineCharge InvoiceLineKind = "charge"
+)
+
+// InvoiceLine is a single entry printed on the invoice.
+type InvoiceLine struct {
+	Kind        InvoiceLineKind
+	Description string
+	Amount      Money
 }
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// OverrideRef identifies the negotiated rate override applied to a calculation.
+// It is kept in results so the ledger can tell which agreement priced the charge.
+type OverrideRef struct {
+	AccountID      string
+	ReasonCode     string
+	EffectiveFrom  time.Time
+	EffectiveUntil time.Time
+}
@@ -3,6 +3,7 @@ package domain
 import "time"
 
 type RotativeBalance struct {
-    Principal Money
-    StartDate time.Time
+	AccountID string
+	Principal Money
+	StartDate time.Time
 }
@@ -0,0 +1,55 @@
+package domain
+
+import "fmt"
+
+// RoundingMode selects how an amount computed in fractions of a centavo is
+// rounded to the centavo. The empty value means RoundHalfUp; unknown values
+// are rejected by UnmarshalText.
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds to the nearest centavo, ties away from zero.
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundHalfEven rounds to the nearest centavo, ties to the even centavo
+	// (banker's rounding).
+	RoundHalfEven RoundingMode = "half_even"
+	// RoundDown truncates towards zero: charges never exceed the exact value,
+	// in favor of the customer.
+	RoundDown RoundingMode = "truncate"
+)
+
+// UnmarshalText accepts the rounding modes and the empty value, so an unknown
+// mode in the environment or in JSON fails to load instead of silently
+// rounding half up.
+func (m *RoundingMode) UnmarshalText(text []byte) error {
+	switch v := RoundingMode(text); v {
+	case "", RoundHalfUp, RoundHalfEven, RoundDown:
+		*m = v
+		return nil
+	}
+	return fmt.Errorf("domain: unknown rounding mode %q, want %q, %q or %q", text, RoundHalfUp, RoundHalfEven, RoundDown)
+}
+
+// RemainderPlacement selects the parcel that takes the centavos left over
+// when an amount is split in equal parcels. The empty value means
+// RemainderFirst.
+type RemainderPlacement string
+
+const (
+	RemainderFirst RemainderPlacement = "first"
+	RemainderLast  RemainderPlacement = "last"
+)
+
+// UnmarshalText accepts RemainderFirst, RemainderLast and the empty value.
+func (p *RemainderPlacement) UnmarshalText(text []byte) error {
+	switch v := RemainderPlacement(text); v {
+	case "", RemainderFirst, RemainderLast:
+		*p = v
+		return nil
+	}
+	return fmt.Errorf("domain: unknown remainder placement %q, want %q or %q", text, RemainderFirst, RemainderLast)
+}
+
+// ResidueDenominator is the unit of rounding residues: a residue of
+// ResidueDenominator equals one centavo.
+const ResidueDenominator = 1_000_000
@@ -0,0 +1,1054 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: charges/v1/charges.proto
+
+package chargesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RotativeBalance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Account used to resolve negotiated rates.
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Principal     int64                  `protobuf:"varint,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotativeBalance) Reset() {
+	*x = RotativeBalance{}
+	mi := &file_charges_v1_charges_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotativeBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotativeBalance) ProtoMessage() {}
+
+func (x *RotativeBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotativeBalance.ProtoReflect.Descriptor instead.
+func (*RotativeBalance) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RotativeBalance) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RotativeBalance) GetPrincipal() int64 {
+	if x != nil {
+		return x.Principal
+	}
+	return 0
+}
+
+func (x *RotativeBalance) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+type OverrideRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ReasonCode    string                 `protobuf:"bytes,2,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	// Unset when the override has no end date.
+	EffectiveUntil *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=effective_until,json=effectiveUntil,proto3" json:"effective_until,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OverrideRef) Reset() {
+	*x = OverrideRef{}
+	mi := &file_charges_v1_charges_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverrideRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverrideRef) ProtoMessage() {}
+
+func (x *OverrideRef) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverrideRef.ProtoReflect.Descriptor instead.
+func (*OverrideRef) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OverrideRef) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *OverrideRef) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *OverrideRef) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *OverrideRef) GetEffectiveUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveUntil
+	}
+	return nil
+}
+
+type RotativeResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Principal    int64                  `protobuf:"varint,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	Interest     int64                  `protobuf:"varint,2,opt,name=interest,proto3" json:"interest,omitempty"`
+	Iof          int64                  `protobuf:"varint,3,opt,name=iof,proto3" json:"iof,omitempty"`
+	LateFee      int64                  `protobuf:"varint,4,opt,name=late_fee,json=lateFee,proto3" json:"late_fee,omitempty"`
+	LateInterest int64                  `protobuf:"varint,5,opt,name=late_interest,json=lateInterest,proto3" json:"late_interest,omitempty"`
+	Charges      int64                  `protobuf:"varint,6,opt,name=charges,proto3" json:"charges,omitempty"`
+	Total        int64                  `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	Days         int32                  `protobuf:"varint,8,opt,name=days,proto3" json:"days,omitempty"`
+	ChargedDays  int32                  `protobuf:"varint,9,opt,name=charged_days,json=chargedDays,proto3" json:"charged_days,omitempty"`
+	ChargeCapped bool                   `protobuf:"varint,10,opt,name=charge_capped,json=chargeCapped,proto3" json:"charge_capped,omitempty"`
+	Override     *OverrideRef           `protobuf:"bytes,11,opt,name=override,proto3" json:"override,omitempty"`
+	// Rounded minus exact charges, in millionths of a centavo.
+	RoundingResidue int64 `protobuf:"varint,12,opt,name=rounding_residue,json=roundingResidue,proto3" json:"rounding_residue,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RotativeResult) Reset() {
+	*x = RotativeResult{}
+	mi := &file_charges_v1_charges_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotativeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotativeResult) ProtoMessage() {}
+
+func (x *RotativeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotativeResult.ProtoReflect.Descriptor instead.
+func (*RotativeResult) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RotativeResult) GetPrincipal() int64 {
+	if x != nil {
+		return x.Principal
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetInterest() int64 {
+	if x != nil {
+		return x.Interest
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetIof() int64 {
+	if x != nil {
+		return x.Iof
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetLateFee() int64 {
+	if x != nil {
+		return x.LateFee
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetLateInterest() int64 {
+	if x != nil {
+		return x.LateInterest
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetCharges() int64 {
+	if x != nil {
+		return x.Charges
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetChargedDays() int32 {
+	if x != nil {
+		return x.ChargedDays
+	}
+	return 0
+}
+
+func (x *RotativeResult) GetChargeCapped() bool {
+	if x != nil {
+		return x.ChargeCapped
+	}
+	return false
+}
+
+func (x *RotativeResult) GetOverride() *OverrideRef {
+	if x != nil {
+		return x.Override
+	}
+	return nil
+}
+
+func (x *RotativeResult) GetRoundingResidue() int64 {
+	if x != nil {
+		return x.RoundingResidue
+	}
+	return 0
+}
+
+type CalculateRotativeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       *RotativeBalance       `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateRotativeRequest) Reset() {
+	*x = CalculateRotativeRequest{}
+	mi := &file_charges_v1_charges_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateRotativeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateRotativeRequest) ProtoMessage() {}
+
+func (x *CalculateRotativeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateRotativeRequest.ProtoReflect.Descriptor instead.
+func (*CalculateRotativeRequest) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CalculateRotativeRequest) GetBalance() *RotativeBalance {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *CalculateRotativeRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type CalculateRotativeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *RotativeResult        `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateRotativeResponse) Reset() {
+	*x = CalculateRotativeResponse{}
+	mi := &file_charges_v1_charges_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateRotativeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateRotativeResponse) ProtoMessage() {}
+
+func (x *CalculateRotativeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateRotativeResponse.ProtoReflect.Descriptor instead.
+func (*CalculateRotativeResponse) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CalculateRotativeResponse) GetResult() *RotativeResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type AmortizationResult struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PaidIof          int64                  `protobuf:"varint,1,opt,name=paid_iof,json=paidIof,proto3" json:"paid_iof,omitempty"`
+	PaidInterest     int64                  `protobuf:"varint,2,opt,name=paid_interest,json=paidInterest,proto3" json:"paid_interest,omitempty"`
+	PaidLateInterest int64                  `protobuf:"varint,3,opt,name=paid_late_interest,json=paidLateInterest,proto3" json:"paid_late_interest,omitempty"`
+	PaidLateFee      int64                  `protobuf:"varint,4,opt,name=paid_late_fee,json=paidLateFee,proto3" json:"paid_late_fee,omitempty"`
+	PaidPrincipal    int64                  `protobuf:"varint,5,opt,name=paid_principal,json=paidPrincipal,proto3" json:"paid_principal,omitempty"`
+	Remaining        int64                  `protobuf:"varint,6,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AmortizationResult) Reset() {
+	*x = AmortizationResult{}
+	mi := &file_charges_v1_charges_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmortizationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmortizationResult) ProtoMessage() {}
+
+func (x *AmortizationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmortizationResult.ProtoReflect.Descriptor instead.
+func (*AmortizationResult) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AmortizationResult) GetPaidIof() int64 {
+	if x != nil {
+		return x.PaidIof
+	}
+	return 0
+}
+
+func (x *AmortizationResult) GetPaidInterest() int64 {
+	if x != nil {
+		return x.PaidInterest
+	}
+	return 0
+}
+
+func (x *AmortizationResult) GetPaidLateInterest() int64 {
+	if x != nil {
+		return x.PaidLateInterest
+	}
+	return 0
+}
+
+func (x *AmortizationResult) GetPaidLateFee() int64 {
+	if x != nil {
+		return x.PaidLateFee
+	}
+	return 0
+}
+
+func (x *AmortizationResult) GetPaidPrincipal() int64 {
+	if x != nil {
+		return x.PaidPrincipal
+	}
+	return 0
+}
+
+func (x *AmortizationResult) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+type ApplyPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *RotativeResult        `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Payment       int64                  `protobuf:"varint,2,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyPaymentRequest) Reset() {
+	*x = ApplyPaymentRequest{}
+	mi := &file_charges_v1_charges_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPaymentRequest) ProtoMessage() {}
+
+func (x *ApplyPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ApplyPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ApplyPaymentRequest) GetResult() *RotativeResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ApplyPaymentRequest) GetPayment() int64 {
+	if x != nil {
+		return x.Payment
+	}
+	return 0
+}
+
+type ApplyPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *AmortizationResult    `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyPaymentResponse) Reset() {
+	*x = ApplyPaymentResponse{}
+	mi := &file_charges_v1_charges_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPaymentResponse) ProtoMessage() {}
+
+func (x *ApplyPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPaymentResponse.ProtoReflect.Descriptor instead.
+func (*ApplyPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ApplyPaymentResponse) GetResult() *AmortizationResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type Installment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	Principal     int64                  `protobuf:"varint,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	Interest      int64                  `protobuf:"varint,4,opt,name=interest,proto3" json:"interest,omitempty"`
+	Iof           int64                  `protobuf:"varint,5,opt,name=iof,proto3" json:"iof,omitempty"`
+	Amount        int64                  `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Installment) Reset() {
+	*x = Installment{}
+	mi := &file_charges_v1_charges_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Installment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Installment) ProtoMessage() {}
+
+func (x *Installment) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Installment.ProtoReflect.Descriptor instead.
+func (*Installment) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Installment) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Installment) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Installment) GetPrincipal() int64 {
+	if x != nil {
+		return x.Principal
+	}
+	return 0
+}
+
+func (x *Installment) GetInterest() int64 {
+	if x != nil {
+		return x.Interest
+	}
+	return 0
+}
+
+func (x *Installment) GetIof() int64 {
+	if x != nil {
+		return x.Iof
+	}
+	return 0
+}
+
+func (x *Installment) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type InstallmentPlan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalAmount   int64                  `protobuf:"varint,1,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	TotalIof      int64                  `protobuf:"varint,2,opt,name=total_iof,json=totalIof,proto3" json:"total_iof,omitempty"`
+	TotalInterest int64                  `protobuf:"varint,3,opt,name=total_interest,json=totalInterest,proto3" json:"total_interest,omitempty"`
+	TotalWithIof  int64                  `protobuf:"varint,4,opt,name=total_with_iof,json=totalWithIof,proto3" json:"total_with_iof,omitempty"`
+	Installments  []*Installment         `protobuf:"bytes,5,rep,name=installments,proto3" json:"installments,omitempty"`
+	Override      *OverrideRef           `protobuf:"bytes,6,opt,name=override,proto3" json:"override,omitempty"`
+	// "issuer" (parcelado emissor) or "merchant" (parcelado lojista).
+	Funding string `protobuf:"bytes,7,opt,name=funding,proto3" json:"funding,omitempty"`
+	// Grace period (carencia) interest capitalized into the PMT; included in
+	// total_interest.
+	GraceInterest int64 `protobuf:"varint,8,opt,name=grace_interest,json=graceInterest,proto3" json:"grace_interest,omitempty"`
+	// Rounded minus exact charges, in millionths of a centavo.
+	RoundingResidue int64 `protobuf:"varint,9,opt,name=rounding_residue,json=roundingResidue,proto3" json:"rounding_residue,omitempty"`
+	// IOF financed inside equal parcels (IOF financiado) instead of added on
+	// top of each installment.
+	IofFinanced   bool `protobuf:"varint,10,opt,name=iof_financed,json=iofFinanced,proto3" json:"iof_financed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallmentPlan) Reset() {
+	*x = InstallmentPlan{}
+	mi := &file_charges_v1_charges_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallmentPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallmentPlan) ProtoMessage() {}
+
+func (x *InstallmentPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallmentPlan.ProtoReflect.Descriptor instead.
+func (*InstallmentPlan) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InstallmentPlan) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetTotalIof() int64 {
+	if x != nil {
+		return x.TotalIof
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetTotalInterest() int64 {
+	if x != nil {
+		return x.TotalInterest
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetTotalWithIof() int64 {
+	if x != nil {
+		return x.TotalWithIof
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetInstallments() []*Installment {
+	if x != nil {
+		return x.Installments
+	}
+	return nil
+}
+
+func (x *InstallmentPlan) GetOverride() *OverrideRef {
+	if x != nil {
+		return x.Override
+	}
+	return nil
+}
+
+func (x *InstallmentPlan) GetFunding() string {
+	if x != nil {
+		return x.Funding
+	}
+	return ""
+}
+
+func (x *InstallmentPlan) GetGraceInterest() int64 {
+	if x != nil {
+		return x.GraceInterest
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetRoundingResidue() int64 {
+	if x != nil {
+		return x.RoundingResidue
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetIofFinanced() bool {
+	if x != nil {
+		return x.IofFinanced
+	}
+	return false
+}
+
+type CalculateInstallmentPlanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Account used to resolve negotiated rates.
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount          int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	NumInstallments int32                  `protobuf:"varint,3,opt,name=num_installments,json=numInstallments,proto3" json:"num_installments,omitempty"`
+	PurchaseDate    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=purchase_date,json=purchaseDate,proto3" json:"purchase_date,omitempty"`
+	FirstDueDate    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=first_due_date,json=firstDueDate,proto3" json:"first_due_date,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CalculateInstallmentPlanRequest) Reset() {
+	*x = CalculateInstallmentPlanRequest{}
+	mi := &file_charges_v1_charges_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateInstallmentPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateInstallmentPlanRequest) ProtoMessage() {}
+
+func (x *CalculateInstallmentPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateInstallmentPlanRequest.ProtoReflect.Descriptor instead.
+func (*CalculateInstallmentPlanRequest) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CalculateInstallmentPlanRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CalculateInstallmentPlanRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CalculateInstallmentPlanRequest) GetNumInstallments() int32 {
+	if x != nil {
+		return x.NumInstallments
+	}
+	return 0
+}
+
+func (x *CalculateInstallmentPlanRequest) GetPurchaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurchaseDate
+	}
+	return nil
+}
+
+func (x *CalculateInstallmentPlanRequest) GetFirstDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstDueDate
+	}
+	return nil
+}
+
+type CalculateInstallmentPlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *InstallmentPlan       `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateInstallmentPlanResponse) Reset() {
+	*x = CalculateInstallmentPlanResponse{}
+	mi := &file_charges_v1_charges_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateInstallmentPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateInstallmentPlanResponse) ProtoMessage() {}
+
+func (x *CalculateInstallmentPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_charges_v1_charges_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateInstallmentPlanResponse.ProtoReflect.Descriptor instead.
+func (*CalculateInstallmentPlanResponse) Descriptor() ([]byte, []int) {
+	return file_charges_v1_charges_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CalculateInstallmentPlanResponse) GetPlan() *InstallmentPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+var File_charges_v1_charges_proto protoreflect.FileDescriptor
+
+const file_charges_v1_charges_proto_rawDesc = "" +
+	"\n" +
+	"\x18charges/v1/charges.proto\x12\n" +
+	"charges.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x01\n" +
+	"\x0fRotativeBalance\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1c\n" +
+	"\tprincipal\x18\x02 \x01(\x03R\tprincipal\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\"\xd5\x01\n" +
+	"\vOverrideRef\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1f\n" +
+	"\vreason_code\x18\x02 \x01(\tR\n" +
+	"reasonCode\x12A\n" +
+	"\x0eeffective_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12C\n" +
+	"\x0feffective_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0eeffectiveUntil\"\x88\x03\n" +
+	"\x0eRotativeResult\x12\x1c\n" +
+	"\tprincipal\x18\x01 \x01(\x03R\tprincipal\x12\x1a\n" +
+	"\binterest\x18\x02 \x01(\x03R\binterest\x12\x10\n" +
+	"\x03iof\x18\x03 \x01(\x03R\x03iof\x12\x19\n" +
+	"\blate_fee\x18\x04 \x01(\x03R\alateFee\x12#\n" +
+	"\rlate_interest\x18\x05 \x01(\x03R\flateInterest\x12\x18\n" +
+	"\acharges\x18\x06 \x01(\x03R\acharges\x12\x14\n" +
+	"\x05total\x18\a \x01(\x03R\x05total\x12\x12\n" +
+	"\x04days\x18\b \x01(\x05R\x04days\x12!\n" +
+	"\fcharged_days\x18\t \x01(\x05R\vchargedDays\x12#\n" +
+	"\rcharge_capped\x18\n" +
+	" \x01(\bR\fchargeCapped\x123\n" +
+	"\boverride\x18\v \x01(\v2\x17.charges.v1.OverrideRefR\boverride\x12)\n" +
+	"\x10rounding_residue\x18\f \x01(\x03R\x0froundingResidue\"}\n" +
+	"\x18CalculateRotativeRequest\x125\n" +
+	"\abalance\x18\x01 \x01(\v2\x1b.charges.v1.RotativeBalanceR\abalance\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\"O\n" +
+	"\x19CalculateRotativeResponse\x122\n" +
+	"\x06result\x18\x01 \x01(\v2\x1a.charges.v1.RotativeResultR\x06result\"\xeb\x01\n" +
+	"\x12AmortizationResult\x12\x19\n" +
+	"\bpaid_iof\x18\x01 \x01(\x03R\apaidIof\x12#\n" +
+	"\rpaid_interest\x18\x02 \x01(\x03R\fpaidInterest\x12,\n" +
+	"\x12paid_late_interest\x18\x03 \x01(\x03R\x10paidLateInterest\x12\"\n" +
+	"\rpaid_late_fee\x18\x04 \x01(\x03R\vpaidLateFee\x12%\n" +
+	"\x0epaid_principal\x18\x05 \x01(\x03R\rpaidPrincipal\x12\x1c\n" +
+	"\tremaining\x18\x06 \x01(\x03R\tremaining\"c\n" +
+	"\x13ApplyPaymentRequest\x122\n" +
+	"\x06result\x18\x01 \x01(\v2\x1a.charges.v1.RotativeResultR\x06result\x12\x18\n" +
+	"\apayment\x18\x02 \x01(\x03R\apayment\"N\n" +
+	"\x14ApplyPaymentResponse\x126\n" +
+	"\x06result\x18\x01 \x01(\v2\x1e.charges.v1.AmortizationResultR\x06result\"\xc0\x01\n" +
+	"\vInstallment\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x125\n" +
+	"\bdue_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\x03R\tprincipal\x12\x1a\n" +
+	"\binterest\x18\x04 \x01(\x03R\binterest\x12\x10\n" +
+	"\x03iof\x18\x05 \x01(\x03R\x03iof\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x03R\x06amount\"\x9f\x03\n" +
+	"\x0fInstallmentPlan\x12!\n" +
+	"\ftotal_amount\x18\x01 \x01(\x03R\vtotalAmount\x12\x1b\n" +
+	"\ttotal_iof\x18\x02 \x01(\x03R\btotalIof\x12%\n" +
+	"\x0etotal_interest\x18\x03 \x01(\x03R\rtotalInterest\x12$\n" +
+	"\x0etotal_with_iof\x18\x04 \x01(\x03R\ftotalWithIof\x12;\n" +
+	"\finstallments\x18\x05 \x03(\v2\x17.charges.v1.InstallmentR\finstallments\x123\n" +
+	"\boverride\x18\x06 \x01(\v2\x17.charges.v1.OverrideRefR\boverride\x12\x18\n" +
+	"\afunding\x18\a \x01(\tR\afunding\x12%\n" +
+	"\x0egrace_interest\x18\b \x01(\x03R\rgraceInterest\x12)\n" +
+	"\x10rounding_residue\x18\t \x01(\x03R\x0froundingResidue\x12!\n" +
+	"\fiof_financed\x18\n" +
+	" \x01(\bR\viofFinanced\"\x86\x02\n" +
+	"\x1fCalculateInstallmentPlanRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12)\n" +
+	"\x10num_installments\x18\x03 \x01(\x05R\x0fnumInstallments\x12?\n" +
+	"\rpurchase_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fpurchaseDate\x12@\n" +
+	"\x0efirst_due_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ffirstDueDate\"S\n" +
+	" CalculateInstallmentPlanResponse\x12/\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1b.charges.v1.InstallmentPlanR\x04plan2\xbe\x01\n" +
+	"\x0fRotativeService\x12X\n" +
+	"\tCalculate\x12$.charges.v1.CalculateRotativeRequest\x1a%.charges.v1.CalculateRotativeResponse\x12Q\n" +
+	"\fApplyPayment\x12\x1f.charges.v1.ApplyPaymentRequest\x1a .charges.v1.ApplyPaymentResponse2|\n" +
+	"\x12InstallmentService\x12f\n" +
+	"\tCalculate\x12+.charges.v1.CalculateInstallmentPlanRequest\x1a,.charges.v1.CalculateInstallmentPlanResponseBHZFgithub.com/thiagozs/go-calc-charges-engine/grpcapi/chargesv1;chargesv1b\x06proto3"
+
+var (
+	file_charges_v1_charges_proto_rawDescOnce sync.Once
+	file_charges_v1_charges_proto_rawDescData []byte
+)
+
+func file_charges_v1_charges_proto_rawDescGZIP() []byte {
+	file_charges_v1_charges_proto_rawDescOnce.Do(func() {
+		file_charges_v1_charges_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_charges_v1_charges_proto_rawDesc), len(file_charges_v1_charges_proto_rawDesc)))
+	})
+	return file_charges_v1_charges_proto_rawDescData
+}
+
+var file_charges_v1_charges_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_charges_v1_charges_proto_goTypes = []any{
+	(*RotativeBalance)(nil),                  // 0: charges.v1.RotativeBalance
+	(*OverrideRef)(nil),                      // 1: charges.v1.OverrideRef
+	(*RotativeResult)(nil),                   // 2: charges.v1.RotativeResult
+	(*CalculateRotativeRequest)(nil),         // 3: charges.v1.CalculateRotativeRequest
+	(*CalculateRotativeResponse)(nil),        // 4: charges.v1.CalculateRotativeResponse
+	(*AmortizationResult)(nil),               // 5: charges.v1.AmortizationResult
+	(*ApplyPaymentRequest)(nil),              // 6: charges.v1.ApplyPaymentRequest
+	(*ApplyPaymentResponse)(nil),             // 7: charges.v1.ApplyPaymentResponse
+	(*Installment)(nil),                      // 8: charges.v1.Installment
+	(*InstallmentPlan)(nil),                  // 9: charges.v1.InstallmentPlan
+	(*CalculateInstallmentPlanRequest)(nil),  // 10: charges.v1.CalculateInstallmentPlanRequest
+	(*CalculateInstallmentPlanResponse)(nil), // 11: charges.v1.CalculateInstallmentPlanResponse
+	(*timestamppb.Timestamp)(nil),            // 12: google.protobuf.Timestamp
+}
+var file_charges_v1_charges_proto_depIdxs = []int32{
+	12, // 0: charges.v1.RotativeBalance.start_date:type_name -> google.protobuf.Timestamp
+	12, // 1: charges.v1.OverrideRef.effective_from:type_name -> google.protobuf.Timestamp
+	12, // 2: charges.v1.OverrideRef.effective_until:type_name -> google.protobuf.Timestamp
+	1,  // 3: charges.v1.RotativeResult.override:type_name -> charges.v1.OverrideRef
+	0,  // 4: charges.v1.CalculateRotativeRequest.balance:type_name -> charges.v1.RotativeBalance
+	12, // 5: charges.v1.CalculateRotativeRequest.at:type_name -> google.protobuf.Timestamp
+	2,  // 6: charges.v1.CalculateRotativeResponse.result:type_name -> charges.v1.RotativeResult
+	2,  // 7: charges.v1.ApplyPaymentRequest.result:type_name -> charges.v1.RotativeResult
+	5,  // 8: charges.v1.ApplyPaymentResponse.result:type_name -> charges.v1.AmortizationResult
+	12, // 9: charges.v1.Installment.due_date:type_name -> google.protobuf.Timestamp
+	8,  // 10: charges.v1.InstallmentPlan.installments:type_name -> charges.v1.Installment
+	1,  // 11: charges.v1.InstallmentPlan.override:type_name -> charges.v1.OverrideRef
+	12, // 12: charges.v1.CalculateInstallmentPlanRequest.purchase_date:type_name -> google.protobuf.Timestamp
+	12, // 13: charges.v1.CalculateInstallmentPlanRequest.first_due_date:type_name -> google.protobuf.Timestamp
+	9,  // 14: charges.v1.CalculateInstallmentPlanResponse.plan:type_name -> charges.v1.InstallmentPlan
+	3,  // 15: charges.v1.RotativeService.Calculate:input_type -> charges.v1.CalculateRotativeRequest
+	6,  // 16: charges.v1.RotativeService.ApplyPayment:input_type -> charges.v1.ApplyPaymentRequest
+	10, // 17: charges.v1.InstallmentService.Calculate:input_type -> charges.v1.CalculateInstallmentPlanRequest
+	4,  // 18: charges.v1.RotativeService.Calculate:output_type -> charges.v1.CalculateRotativeResponse
+	7,  // 19: charges.v1.RotativeService.ApplyPayment:output_type -> charges.v1.ApplyPaymentResponse
+	11, // 20: charges.v1.InstallmentService.Calculate:output_type -> charges.v1.CalculateInstallmentPlanResponse
+	18, // [18:21] is the sub-list for method output_type
+	15, // [15:18] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_charges_v1_charges_proto_init() }
+func file_charges_v1_charges_proto_init() {
+	if File_charges_v1_charges_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_charges_v1_charges_proto_rawDesc), len(file_charges_v1_charges_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_charges_v1_charges_proto_goTypes,
+		DependencyIndexes: file_charges_v1_charges_proto_depIdxs,
+		MessageInfos:      file_charges_v1_charges_proto_msgTypes,
+	}.Build()
+	File_charges_v1_charges_proto = out.File
+	file_charges_v1_charges_proto_goTypes = nil
+	file_charges_v1_charges_proto_depIdxs = nil
+}
@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: charges/v1/charges.proto
+
+package chargesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RotativeService_Calculate_FullMethodName    = "/charges.v1.RotativeService/Calculate"
+	RotativeService_ApplyPayment_FullMethodName = "/charges.v1.RotativeService/ApplyPayment"
+)
+
+// RotativeServiceClient is the client API for RotativeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RotativeService mirrors service.RotativeService.
+type RotativeServiceClient interface {
+	// Calculate computes the rotative charges for a balance at a date.
+	Calculate(ctx context.Context, in *CalculateRotativeRequest, opts ...grpc.CallOption) (*CalculateRotativeResponse, error)
+	// ApplyPayment applies a payment to the buckets of a rotative result
+	// (IOF -> juros -> mora -> multa -> principal).
+	ApplyPayment(ctx context.Context, in *ApplyPaymentRequest, opts ...grpc.CallOption) (*ApplyPaymentResponse, error)
+}
+
+type rotativeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRotativeServiceClient(cc grpc.ClientConnInterface) RotativeServiceClient {
+	return &rotativeServiceClient{cc}
+}
+
+func (c *rotativeServiceClient) Calculate(ctx context.Context, in *CalculateRotativeRequest, opts ...grpc.CallOption) (*CalculateRotativeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateRotativeResponse)
+	err := c.cc.Invoke(ctx, RotativeService_Calculate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotativeServiceClient) ApplyPayment(ctx context.Context, in *ApplyPaymentRequest, opts ...grpc.CallOption) (*ApplyPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyPaymentResponse)
+	err := c.cc.Invoke(ctx, RotativeService_ApplyPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RotativeServiceServer is the server API for RotativeService service.
+// All implementations must embed UnimplementedRotativeServiceServer
+// for forward compatibility.
+//
+// RotativeService mirrors service.RotativeService.
+type RotativeServiceServer interface {
+	// Calculate computes the rotative charges for a balance at a date.
+	Calculate(context.Context, *CalculateRotativeRequest) (*CalculateRotativeResponse, error)
+	// ApplyPayment applies a payment to the buckets of a rotative result
+	// (IOF -> juros -> mora -> multa -> principal).
+	ApplyPayment(context.Context, *ApplyPaymentRequest) (*ApplyPaymentResponse, error)
+	mustEmbedUnimplementedRotativeServiceServer()
+}
+
+// UnimplementedRotativeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRotativeServiceServer struct{}
+
+func (UnimplementedRotativeServiceServer) Calculate(context.Context, *CalculateRotativeRequest) (*CalculateRotativeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedRotativeServiceServer) ApplyPayment(context.Context, *ApplyPaymentRequest) (*ApplyPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyPayment not implemented")
+}
+func (UnimplementedRotativeServiceServer) mustEmbedUnimplementedRotativeServiceServer() {}
+func (UnimplementedRotativeServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeRotativeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RotativeServiceServer will
+// result in compilation errors.
+type UnsafeRotativeServiceServer interface {
+	mustEmbedUnimplementedRotativeServiceServer()
+}
+
+func RegisterRotativeServiceServer(s grpc.ServiceRegistrar, srv RotativeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRotativeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RotativeService_ServiceDesc, srv)
+}
+
+func _RotativeService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateRotativeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotativeServiceServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RotativeService_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotativeServiceServer).Calculate(ctx, req.(*CalculateRotativeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RotativeService_ApplyPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotativeServiceServer).ApplyPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RotativeService_ApplyPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotativeServiceServer).ApplyPayment(ctx, req.(*ApplyPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RotativeService_ServiceDesc is the grpc.ServiceDesc for RotativeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RotativeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "charges.v1.RotativeService",
+	HandlerType: (*RotativeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Calculate",
+			Handler:    _RotativeService_Calculate_Handler,
+		},
+		{
+			MethodName: "ApplyPayment",
+			Handler:    _RotativeService_ApplyPayment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "charges/v1/charges.proto",
+}
+
+const (
+	InstallmentService_Calculate_FullMethodName = "/charges.v1.InstallmentService/Calculate"
+)
+
+// InstallmentServiceClient is the client API for InstallmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// InstallmentService mirrors service.InstallmentService.
+type InstallmentServiceClient interface {
+	// Calculate generates the installment plan of a purchase.
+	Calculate(ctx context.Context, in *CalculateInstallmentPlanRequest, opts ...grpc.CallOption) (*CalculateInstallmentPlanResponse, error)
+}
+
+type installmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInstallmentServiceClient(cc grpc.ClientConnInterface) InstallmentServiceClient {
+	return &installmentServiceClient{cc}
+}
+
+func (c *installmentServiceClient) Calculate(ctx context.Context, in *CalculateInstallmentPlanRequest, opts ...grpc.CallOption) (*CalculateInstallmentPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateInstallmentPlanResponse)
+	err := c.cc.Invoke(ctx, InstallmentService_Calculate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InstallmentServiceServer is the server API for InstallmentService service.
+// All implementations must embed UnimplementedInstallmentServiceServer
+// for forward compatibility.
+//
+// InstallmentService mirrors service.InstallmentService.
+type InstallmentServiceServer interface {
+	// Calculate generates the installment plan of a purchase.
+	Calculate(context.Context, *CalculateInstallmentPlanRequest) (*CalculateInstallmentPlanResponse, error)
+	mustEmbedUnimplementedInstallmentServiceServer()
+}
+
+// UnimplementedInstallmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInstallmentServiceServer struct{}
+
+func (UnimplementedInstallmentServiceServer) Calculate(context.Context, *CalculateInstallmentPlanRequest) (*CalculateInstallmentPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedInstallmentServiceServer) mustEmbedUnimplementedInstallmentServiceServer() {}
+func (UnimplementedInstallmentServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeInstallmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InstallmentServiceServer will
+// result in compilation errors.
+type UnsafeInstallmentServiceServer interface {
+	mustEmbedUnimplementedInstallmentServiceServer()
+}
+
+func RegisterInstallmentServiceServer(s grpc.ServiceRegistrar, srv InstallmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInstallmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InstallmentService_ServiceDesc, srv)
+}
+
+func _InstallmentService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateInstallmentPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstallmentServiceServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstallmentService_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstallmentServiceServer).Calculate(ctx, req.(*CalculateInstallmentPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InstallmentService_ServiceDesc is the grpc.ServiceDesc for InstallmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InstallmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "charges.v1.InstallmentService",
+	HandlerType: (*InstallmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Calculate",
+			Handler:    _InstallmentService_Calculate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "charges/v1/charges.proto",
+}
@@ -0,0 +1,94 @@
+package grpcapi
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+	"github.com/thiagozs/go-calc-charges-engine/grpcapi/chargesv1"
+)
+
+func toRotativeResult(r calc.RotativeResult) *chargesv1.RotativeResult {
+	return &chargesv1.RotativeResult{
+		Principal:       int64(r.Principal),
+		Interest:        int64(r.Interest),
+		Iof:             int64(r.IOF),
+		LateFee:         int64(r.LateFee),
+		LateInterest:    int64(r.LateInterest),
+		Charges:         int64(r.Charges),
+		Total:           int64(r.Total),
+		Days:            int32(r.Days),
+		ChargedDays:     int32(r.ChargedDays),
+		ChargeCapped:    r.ChargeCapped,
+		RoundingResidue: r.RoundingResidue,
+		Override:        toOverrideRef(r.Override),
+	}
+}
+
+func fromRotativeResult(r *chargesv1.RotativeResult) calc.RotativeResult {
+	return calc.RotativeResult{
+		Principal:       domain.Money(r.GetPrincipal()),
+		Interest:        domain.Money(r.GetInterest()),
+		IOF:             domain.Money(r.GetIof()),
+		LateFee:         domain.Money(r.GetLateFee()),
+		LateInterest:    domain.Money(r.GetLateInterest()),
+		Charges:         domain.Money(r.GetCharges()),
+		Total:           domain.Money(r.GetTotal()),
+		Days:            int(r.GetDays()),
+		ChargedDays:     int(r.GetChargedDays()),
+		ChargeCapped:    r.GetChargeCapped(),
+		RoundingResidue: r.GetRoundingResidue(),
+	}
+}
+
+func toAmortizationResult(a calc.AmortizationResult) *chargesv1.AmortizationResult {
+	return &chargesv1.AmortizationResult{
+		PaidIof:          int64(a.PaidIOF),
+		PaidInterest:     int64(a.PaidInterest),
+		PaidLateInterest: int64(a.PaidLateInterest),
+		PaidLateFee:      int64(a.PaidLateFee),
+		PaidPrincipal:    int64(a.PaidPrincipal),
+		Remaining:        int64(a.Remaining),
+	}
+}
+
+func toInstallmentPlan(p domain.InstallmentPlan) *chargesv1.InstallmentPlan {
+	plan := &chargesv1.InstallmentPlan{
+		TotalAmount:     int64(p.TotalAmount),
+		TotalIof:        int64(p.TotalIOF),
+		TotalInterest:   int64(p.TotalInterest),
+		TotalWithIof:    int64(p.TotalWithIOF),
+		Installments:    make([]*chargesv1.Installment, len(p.Installments)),
+		Override:        toOverrideRef(p.Override),
+		Funding:         string(p.Funding),
+		GraceInterest:   int64(p.GraceInterest),
+		RoundingResidue: p.RoundingResidue,
+		IofFinanced:     p.IOFFinanced,
+	}
+	for i, inst := range p.Installments {
+		plan.Installments[i] = &chargesv1.Installment{
+			Number:    int32(inst.Number),
+			DueDate:   timestamppb.New(inst.DueDate),
+			Principal: int64(inst.Principal),
+			Interest:  int64(inst.Interest),
+			Iof:       int64(inst.IOF),
+			Amount:    int64(inst.Amount),
+		}
+	}
+	return plan
+}
+
+func toOverrideRef(ref *domain.OverrideRef) *chargesv1.OverrideRef {
+	if ref == nil {
+		return nil
+	}
+	o := &chargesv1.OverrideRef{
+		AccountId:     ref.AccountID,
+		ReasonCode:    ref.ReasonCode,
+		EffectiveFrom: timestamppb.New(ref.EffectiveFrom),
+	}
+	if !ref.EffectiveUntil.IsZero() {
+		o.EffectiveUntil = timestamppb.New(ref.EffectiveUntil)
+	}
+	return o
+}
@@ -0,0 +1,115 @@
+// Package grpcapi implements the charges.v1 gRPC services on top of the
+// service package. Generated code lives in chargesv1; regenerate it with
+// go generate after editing proto/charges/v1/charges.proto.
+package grpcapi
+
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/thiagozs/go-calc-charges-engine --go-grpc_out=.. --go-grpc_opt=module=github.com/thiagozs/go-calc-charges-engine charges/v1/charges.proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+	"github.com/thiagozs/go-calc-charges-engine/grpcapi/chargesv1"
+	"github.com/thiagozs/go-calc-charges-engine/service"
+)
+
+// MaxInstallments bounds the installments accepted by the API.
+const MaxInstallments = 48
+
+// RotativeServer implements chargesv1.RotativeServiceServer.
+type RotativeServer struct {
+	chargesv1.UnimplementedRotativeServiceServer
+	Service *service.RotativeService
+}
+
+// InstallmentServer implements chargesv1.InstallmentServiceServer.
+type InstallmentServer struct {
+	chargesv1.UnimplementedInstallmentServiceServer
+	Service *service.InstallmentService
+}
+
+// Register registers both services on s, backed by services built from cfg.
+func Register(s grpc.ServiceRegistrar, cfg config.EngineConfig) {
+	chargesv1.RegisterRotativeServiceServer(s, &RotativeServer{Service: service.NewRotativeService(cfg)})
+	chargesv1.RegisterInstallmentServiceServer(s, &InstallmentServer{Service: service.NewInstallmentService(cfg)})
+}
+
+func (s *RotativeServer) Calculate(_ context.Context, req *chargesv1.CalculateRotativeRequest) (*chargesv1.CalculateRotativeResponse, error) {
+	b := req.GetBalance()
+	if b == nil {
+		return nil, status.Error(codes.InvalidArgument, "balance is required")
+	}
+	if b.GetPrincipal() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "principal must not be negative")
+	}
+	if b.GetPrincipal() > calc.MaxAmount {
+		return nil, status.Errorf(codes.InvalidArgument, "principal must not exceed %d", calc.MaxAmount)
+	}
+	if b.GetStartDate() == nil || req.GetAt() == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_date and at are required")
+	}
+	start, at := b.GetStartDate().AsTime(), req.GetAt().AsTime()
+	if at.Before(start) {
+		return nil, status.Error(codes.InvalidArgument, "at must not be before start_date")
+	}
+
+	result := s.Service.Calculate(domain.RotativeBalance{
+		AccountID: b.GetAccountId(),
+		Principal: domain.Money(b.GetPrincipal()),
+		StartDate: start,
+	}, at)
+	return &chargesv1.CalculateRotativeResponse{Result: toRotativeResult(result)}, nil
+}
+
+func (s *RotativeServer) ApplyPayment(_ context.Context, req *chargesv1.ApplyPaymentRequest) (*chargesv1.ApplyPaymentResponse, error) {
+	r := req.GetResult()
+	if r == nil {
+		return nil, status.Error(codes.InvalidArgument, "result is required")
+	}
+	if req.GetPayment() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "payment must not be negative")
+	}
+	if r.GetPrincipal() < 0 || r.GetInterest() < 0 || r.GetIof() < 0 || r.GetLateFee() < 0 || r.GetLateInterest() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "result buckets must not be negative")
+	}
+	for _, v := range []int64{req.GetPayment(), r.GetTotal(), r.GetPrincipal(), r.GetInterest(), r.GetIof(), r.GetLateFee(), r.GetLateInterest()} {
+		if v > calc.MaxAmount {
+			return nil, status.Errorf(codes.InvalidArgument, "payment and result buckets must not exceed %d", calc.MaxAmount)
+		}
+	}
+	if r.GetTotal() != r.GetPrincipal()+r.GetInterest()+r.GetIof()+r.GetLateFee()+r.GetLateInterest() {
+		return nil, status.Error(codes.InvalidArgument, "total must equal principal plus charges")
+	}
+
+	applied := s.Service.ApplyPayment(fromRotativeResult(r), domain.Money(req.GetPayment()))
+	return &chargesv1.ApplyPaymentResponse{Result: toAmortizationResult(applied)}, nil
+}
+
+func (s *InstallmentServer) Calculate(_ context.Context, req *chargesv1.CalculateInstallmentPlanRequest) (*chargesv1.CalculateInstallmentPlanResponse, error) {
+	if req.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	if req.GetAmount() > calc.MaxAmount {
+		return nil, status.Errorf(codes.InvalidArgument, "amount must not exceed %d", calc.MaxAmount)
+	}
+	n := int(req.GetNumInstallments())
+	if n < 1 || n > MaxInstallments {
+		return nil, status.Errorf(codes.InvalidArgument, "num_installments must be between 1 and %d", MaxInstallments)
+	}
+	if req.GetPurchaseDate() == nil || req.GetFirstDueDate() == nil {
+		return nil, status.Error(codes.InvalidArgument, "purchase_date and first_due_date are required")
+	}
+	purchase, firstDue := req.GetPurchaseDate().AsTime(), req.GetFirstDueDate().AsTime()
+	if !firstDue.After(purchase) {
+		return nil, status.Error(codes.InvalidArgument, "first_due_date must be after purchase_date")
+	}
+
+	plan := s.Service.CalculateForAccount(req.GetAccountId(), domain.Money(req.GetAmount()), n, purchase, firstDue)
+	return &chargesv1.CalculateInstallmentPlanResponse{Plan: toInstallmentPlan(plan)}, nil
+}
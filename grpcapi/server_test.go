@@ -0,0 +1,167 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/grpcapi/chargesv1"
+)
+
+func testConfig() config.EngineConfig {
+	return config.EngineConfig{
+		IOF:          config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800},
+		Interest:     config.InterestConfig{MonthlyRate: 120_000},
+		LateFee:      config.LateFeeConfig{Rate: 20_000},
+		LateInterest: config.LateInterestConfig{MonthlyRate: 10_000},
+		Rules:        config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 1_000_000},
+		Installment:  config.InstallmentConfig{MonthlyRate: 19_900},
+	}
+}
+
+// dial starts an in-memory gRPC server and returns a client connection to it.
+func dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	Register(srv, testConfig())
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func ts(y int, m time.Month, d int) *timestamppb.Timestamp {
+	return timestamppb.New(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+func TestRotativeService_CalculateAndPay(t *testing.T) {
+	client := chargesv1.NewRotativeServiceClient(dial(t))
+	ctx := context.Background()
+
+	resp, err := client.Calculate(ctx, &chargesv1.CalculateRotativeRequest{
+		Balance: &chargesv1.RotativeBalance{Principal: 100_000, StartDate: ts(2024, 1, 1)},
+		At:      ts(2024, 1, 31),
+	})
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	r := resp.GetResult()
+	if r.GetInterest() != 12_000 || r.GetIof() != 626 || r.GetTotal() != 115_626 {
+		t.Fatalf("unexpected result: %v", r)
+	}
+
+	paid, err := client.ApplyPayment(ctx, &chargesv1.ApplyPaymentRequest{Result: r, Payment: 40_000})
+	if err != nil {
+		t.Fatalf("ApplyPayment: %v", err)
+	}
+	if paid.GetResult().GetRemaining() != 75_626 {
+		t.Fatalf("expected remaining 75626, got %d", paid.GetResult().GetRemaining())
+	}
+}
+
+func TestInstallmentService_Calculate(t *testing.T) {
+	client := chargesv1.NewInstallmentServiceClient(dial(t))
+
+	resp, err := client.Calculate(context.Background(), &chargesv1.CalculateInstallmentPlanRequest{
+		Amount:          100_000,
+		NumInstallments: 12,
+		PurchaseDate:    ts(2024, 1, 5),
+		FirstDueDate:    ts(2024, 2, 10),
+	})
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	plan := resp.GetPlan()
+	if len(plan.GetInstallments()) != 12 {
+		t.Fatalf("expected 12 installments, got %d", len(plan.GetInstallments()))
+	}
+	last := plan.GetInstallments()[11].GetDueDate().AsTime()
+	if !last.Equal(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected last due date 2025-01-10, got %v", last)
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	conn := dial(t)
+	ctx := context.Background()
+
+	_, err := chargesv1.NewRotativeServiceClient(conn).Calculate(ctx, &chargesv1.CalculateRotativeRequest{
+		Balance: &chargesv1.RotativeBalance{Principal: -1, StartDate: ts(2024, 1, 1)},
+		At:      ts(2024, 1, 31),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for negative principal, got %v", err)
+	}
+
+	_, err = chargesv1.NewInstallmentServiceClient(conn).Calculate(ctx, &chargesv1.CalculateInstallmentPlanRequest{
+		Amount:          100_000,
+		NumInstallments: 0,
+		PurchaseDate:    ts(2024, 1, 5),
+		FirstDueDate:    ts(2024, 2, 10),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for zero installments, got %v", err)
+	}
+}
+
+func TestInvalidArguments_AboveMaxAmount(t *testing.T) {
+	conn := dial(t)
+	ctx := context.Background()
+	rotative := chargesv1.NewRotativeServiceClient(conn)
+
+	_, err := rotative.Calculate(ctx, &chargesv1.CalculateRotativeRequest{
+		Balance: &chargesv1.RotativeBalance{Principal: 9_000_000_000_000_000_000, StartDate: ts(2024, 1, 1)},
+		At:      ts(2024, 1, 31),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a principal above MaxAmount, got %v", err)
+	}
+
+	_, err = rotative.ApplyPayment(ctx, &chargesv1.ApplyPaymentRequest{
+		Result:  &chargesv1.RotativeResult{Principal: 100, Total: 100},
+		Payment: calc.MaxAmount + 1,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a payment above MaxAmount, got %v", err)
+	}
+
+	_, err = chargesv1.NewInstallmentServiceClient(conn).Calculate(ctx, &chargesv1.CalculateInstallmentPlanRequest{
+		Amount:          calc.MaxAmount + 1,
+		NumInstallments: 12,
+		PurchaseDate:    ts(2024, 1, 5),
+		FirstDueDate:    ts(2024, 2, 10),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an amount above MaxAmount, got %v", err)
+	}
+
+	// The bound itself is accepted.
+	resp, err := rotative.Calculate(ctx, &chargesv1.CalculateRotativeRequest{
+		Balance: &chargesv1.RotativeBalance{Principal: calc.MaxAmount, StartDate: ts(2024, 1, 1)},
+		At:      ts(2024, 1, 31),
+	})
+	if err != nil || resp.GetResult().GetTotal() < calc.MaxAmount {
+		t.Fatalf("expected a result at MaxAmount, got %v (%v)", resp.GetResult(), err)
+	}
+}
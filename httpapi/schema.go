@@ -0,0 +1,20 @@
+package httpapi
+
+import (
+	"embed"
+	"fmt"
+	"path"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Schema returns the JSON Schema document with the given name,
+// e.g. "rotative_request" or "installment_plan_response".
+func Schema(name string) ([]byte, error) {
+	b, err := schemaFS.ReadFile(path.Join("schemas", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("schema %q not found", name)
+	}
+	return b, nil
+}
@@ -0,0 +1,156 @@
+// Package httpapi exposes the engine over HTTP with JSON bodies.
+//
+// Endpoints:
+//
+//	POST /v1/rotative          RotativeRequest    -> RotativeResponse
+//	POST /v1/installments      InstallmentRequest -> InstallmentPlanResponse
+//	POST /v1/payments          PaymentRequest     -> PaymentResponse
+//	POST /v1/iof               IOFRequest         -> IOFResponse
+//	GET  /v1/schemas/{name}    JSON Schema of a request or response
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/service"
+)
+
+// MaxInstallments bounds the installments accepted by the API.
+const MaxInstallments = 48
+
+// maxBodyBytes bounds request bodies; every request is a small JSON object.
+const maxBodyBytes = 1 << 20
+
+// Server holds the services backing the HTTP endpoints.
+type Server struct {
+	Rotative    *service.RotativeService
+	Installment *service.InstallmentService
+	IOF         *service.IOFService
+}
+
+// New builds a Server whose services share the given config.
+func New(cfg config.EngineConfig) *Server {
+	return &Server{
+		Rotative:    service.NewRotativeService(cfg),
+		Installment: service.NewInstallmentService(cfg),
+		IOF:         service.NewIOFService(cfg),
+	}
+}
+
+// Handler returns the HTTP handler with all routes registered.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/rotative", s.handleRotative)
+	mux.HandleFunc("POST /v1/installments", s.handleInstallments)
+	mux.HandleFunc("POST /v1/payments", s.handlePayments)
+	mux.HandleFunc("POST /v1/iof", s.handleIOF)
+	mux.HandleFunc("GET /v1/schemas/{name}", handleSchema)
+	return mux
+}
+
+func (s *Server) handleRotative(w http.ResponseWriter, r *http.Request) {
+	var req RotativeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	balance := req.Balance()
+	result := s.Rotative.Calculate(balance, req.CalcDate.Time)
+	writeJSON(w, http.StatusOK, NewRotativeResponse(result))
+}
+
+func (s *Server) handleInstallments(w http.ResponseWriter, r *http.Request) {
+	var req InstallmentRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	plan := s.Installment.CalculateForAccount(
+		req.AccountID, req.Amount, req.Installments,
+		req.PurchaseDate.Time, req.FirstDueDate.Time,
+	)
+	writeJSON(w, http.StatusOK, NewInstallmentPlanResponse(plan))
+}
+
+func (s *Server) handlePayments(w http.ResponseWriter, r *http.Request) {
+	var req PaymentRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result := s.Rotative.ApplyPayment(req.RotativeResult(), req.Payment)
+	writeJSON(w, http.StatusOK, NewPaymentResponse(result))
+}
+
+func (s *Server) handleIOF(w http.ResponseWriter, r *http.Request) {
+	var req IOFRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var resp IOFResponse
+	if req.International {
+		resp.IOF = s.IOF.CalculateInternational(req.Amount)
+	} else {
+		resp.IOF = s.IOF.Calculate(req.Amount, req.Days)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func handleSchema(w http.ResponseWriter, r *http.Request) {
+	b, err := Schema(r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
+}
+
+// decode reads a single JSON object into v, rejecting unknown fields.
+// It writes the error response and returns false on failure.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, errors.New("invalid request body: trailing data"))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,194 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+)
+
+func testServer() *httptest.Server {
+	cfg := config.EngineConfig{
+		IOF:              config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800},
+		Interest:         config.InterestConfig{MonthlyRate: 120_000},
+		LateFee:          config.LateFeeConfig{Rate: 20_000},
+		LateInterest:     config.LateInterestConfig{MonthlyRate: 10_000},
+		Rules:            config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 1_000_000},
+		InternationalIOF: config.InternationalIOFConfig{Rate: 35_000},
+		Installment:      config.InstallmentConfig{MonthlyRate: 19_900},
+	}
+	return httptest.NewServer(New(cfg).Handler())
+}
+
+func post(t *testing.T, srv *httptest.Server, path, body string, out any) int {
+	t.Helper()
+	resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("decode %s response: %v", path, err)
+	}
+	return resp.StatusCode
+}
+
+func TestRotativeEndpoint(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+
+	var resp RotativeResponse
+	status := post(t, srv, "/v1/rotative",
+		`{"principal":100000,"start_date":"2024-01-01","calc_date":"2024-01-31"}`, &resp)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if resp.Interest != 12_000 || resp.IOF != 626 || resp.LateFee != 2_000 || resp.LateInterest != 1_000 {
+		t.Fatalf("unexpected charges: %+v", resp)
+	}
+	if resp.Days != 30 || resp.Total != 115_626 {
+		t.Fatalf("unexpected days/total: %+v", resp)
+	}
+}
+
+func TestInstallmentsEndpoint(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+
+	var resp InstallmentPlanResponse
+	status := post(t, srv, "/v1/installments",
+		`{"amount":100000,"installments":12,"purchase_date":"2024-01-05","first_due_date":"2024-02-10"}`, &resp)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if len(resp.Installments) != 12 {
+		t.Fatalf("expected 12 installments, got %d", len(resp.Installments))
+	}
+	if got := resp.Installments[11].DueDate.Format(dateLayout); got != "2025-01-10" {
+		t.Fatalf("expected last due date 2025-01-10, got %s", got)
+	}
+	if resp.TotalInterest <= 0 {
+		t.Fatalf("expected interest, got %d", resp.TotalInterest)
+	}
+}
+
+func TestPaymentsEndpoint(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+
+	var resp PaymentResponse
+	status := post(t, srv, "/v1/payments",
+		`{"total":123000,"iof":6000,"interest":14000,"late_interest":1000,"late_fee":2000,"principal":100000,"payment":40000}`, &resp)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if resp.PaidPrincipal != 17_000 || resp.Remaining != 83_000 {
+		t.Fatalf("unexpected amortization: %+v", resp)
+	}
+}
+
+func TestIOFEndpoint(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+
+	var resp IOFResponse
+	if status := post(t, srv, "/v1/iof", `{"amount":100000,"days":30}`, &resp); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if resp.IOF != 626 {
+		t.Fatalf("expected IOF 626, got %d", resp.IOF)
+	}
+
+	if status := post(t, srv, "/v1/iof", `{"amount":12345,"international":true}`, &resp); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if resp.IOF != 432 {
+		t.Fatalf("expected international IOF 432, got %d", resp.IOF)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"negative principal", "/v1/rotative", `{"principal":-1,"start_date":"2024-01-01","calc_date":"2024-01-31"}`},
+		{"invalid date", "/v1/rotative", `{"principal":100,"start_date":"01/01/2024","calc_date":"2024-01-31"}`},
+		{"unknown field", "/v1/rotative", `{"principal":100,"start_date":"2024-01-01","calc_date":"2024-01-31","rate":1}`},
+		{"too many installments", "/v1/installments", `{"amount":100,"installments":99,"purchase_date":"2024-01-05","first_due_date":"2024-02-10"}`},
+		{"inconsistent total", "/v1/payments", `{"total":1,"iof":0,"interest":0,"late_interest":0,"late_fee":0,"principal":100,"payment":10}`},
+		{"negative days", "/v1/iof", `{"amount":100,"days":-1}`},
+		{"principal above MaxAmount", "/v1/rotative", `{"principal":1000000000000001,"start_date":"2024-01-01","calc_date":"2024-01-31"}`},
+		{"amount above MaxAmount", "/v1/installments", `{"amount":1000000000000001,"installments":12,"purchase_date":"2024-01-05","first_due_date":"2024-02-10"}`},
+		{"payment above MaxAmount", "/v1/payments", `{"total":100,"iof":0,"interest":0,"late_interest":0,"late_fee":0,"principal":100,"payment":1000000000000001}`},
+		{"IOF amount above MaxAmount", "/v1/iof", `{"amount":9000000000000000000,"days":10}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ErrorResponse
+			if status := post(t, srv, tt.path, tt.body, &resp); status != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", status)
+			}
+			if resp.Error == "" {
+				t.Fatalf("expected error message")
+			}
+		})
+	}
+}
+
+func TestRotativeEndpoint_MaxAmount(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+
+	body := fmt.Sprintf(`{"principal":%d,"start_date":"2024-01-01","calc_date":"2024-01-31"}`, calc.MaxAmount)
+	var resp RotativeResponse
+	if status := post(t, srv, "/v1/rotative", body, &resp); status != http.StatusOK {
+		t.Fatalf("expected 200 at MaxAmount, got %d", status)
+	}
+	if resp.Total < calc.MaxAmount {
+		t.Fatalf("expected a total above the principal, got %d", resp.Total)
+	}
+}
+
+func TestSchemaEndpoint(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/schemas/rotative_request")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var schema map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		t.Fatalf("decode schema: %v", err)
+	}
+	if schema["title"] != "RotativeRequest" {
+		t.Fatalf("unexpected schema title %v", schema["title"])
+	}
+
+	missing, err := http.Get(srv.URL + "/v1/schemas/nope")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	missing.Body.Close()
+	if missing.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", missing.StatusCode)
+	}
+}
@@ -0,0 +1,205 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// dateLayout is the ISO 8601 calendar date used by every date field.
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date encoded as "YYYY-MM-DD" and interpreted in UTC.
+type Date struct {
+	time.Time
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(dateLayout))
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("date must be a string in YYYY-MM-DD format")
+	}
+	t, err := time.ParseInLocation(dateLayout, s, time.UTC)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", s)
+	}
+	d.Time = t
+	return nil
+}
+
+// All monetary fields are int64 centavos (domain.Money) and all rates are
+// int64 millionths (domain.Rate), matching the engine types.
+
+type RotativeRequest struct {
+	AccountID string       `json:"account_id,omitempty"`
+	Principal domain.Money `json:"principal"`
+	StartDate Date         `json:"start_date"`
+	CalcDate  Date         `json:"calc_date"`
+}
+
+type RotativeResponse struct {
+	Principal       domain.Money `json:"principal"`
+	Interest        domain.Money `json:"interest"`
+	IOF             domain.Money `json:"iof"`
+	LateFee         domain.Money `json:"late_fee"`
+	LateInterest    domain.Money `json:"late_interest"`
+	Charges         domain.Money `json:"charges"`
+	Total           domain.Money `json:"total"`
+	Days            int          `json:"days"`
+	ChargedDays     int          `json:"charged_days"`
+	ChargeCapped    bool         `json:"charge_capped"`
+	RoundingResidue int64        `json:"rounding_residue,omitempty"`
+	Override        *Override    `json:"override,omitempty"`
+}
+
+type Override struct {
+	AccountID      string `json:"account_id"`
+	ReasonCode     string `json:"reason_code"`
+	EffectiveFrom  Date   `json:"effective_from"`
+	EffectiveUntil *Date  `json:"effective_until,omitempty"`
+}
+
+type InstallmentRequest struct {
+	AccountID    string       `json:"account_id,omitempty"`
+	Amount       domain.Money `json:"amount"`
+	Installments int          `json:"installments"`
+	PurchaseDate Date         `json:"purchase_date"`
+	FirstDueDate Date         `json:"first_due_date"`
+}
+
+type InstallmentPlanResponse struct {
+	TotalAmount     domain.Money          `json:"total_amount"`
+	TotalIOF        domain.Money          `json:"total_iof"`
+	TotalInterest   domain.Money          `json:"total_interest"`
+	TotalWithIOF    domain.Money          `json:"total_with_iof"`
+	GraceInterest   domain.Money          `json:"grace_interest,omitempty"`
+	RoundingResidue int64                 `json:"rounding_residue,omitempty"`
+	IOFFinanced     bool                  `json:"iof_financed,omitempty"`
+	Funding         string                `json:"funding"`
+	Installments    []InstallmentResponse `json:"installments"`
+	Override        *Override             `json:"override,omitempty"`
+}
+
+type InstallmentResponse struct {
+	Number    int          `json:"number"`
+	DueDate   Date         `json:"due_date"`
+	Principal domain.Money `json:"principal"`
+	Interest  domain.Money `json:"interest"`
+	IOF       domain.Money `json:"iof"`
+	Amount    domain.Money `json:"amount"`
+}
+
+// PaymentRequest carries the buckets of a rotative result and the payment to apply.
+type PaymentRequest struct {
+	Total        domain.Money `json:"total"`
+	IOF          domain.Money `json:"iof"`
+	Interest     domain.Money `json:"interest"`
+	LateInterest domain.Money `json:"late_interest"`
+	LateFee      domain.Money `json:"late_fee"`
+	Principal    domain.Money `json:"principal"`
+	Payment      domain.Money `json:"payment"`
+}
+
+type PaymentResponse struct {
+	PaidIOF          domain.Money `json:"paid_iof"`
+	PaidInterest     domain.Money `json:"paid_interest"`
+	PaidLateInterest domain.Money `json:"paid_late_interest"`
+	PaidLateFee      domain.Money `json:"paid_late_fee"`
+	PaidPrincipal    domain.Money `json:"paid_principal"`
+	Remaining        domain.Money `json:"remaining"`
+}
+
+// IOFRequest computes the credit IOF over Days, or the per-transaction IOF
+// when International is set (Days is then ignored).
+type IOFRequest struct {
+	Amount        domain.Money `json:"amount"`
+	Days          int          `json:"days"`
+	International bool         `json:"international,omitempty"`
+}
+
+type IOFResponse struct {
+	IOF domain.Money `json:"iof"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewRotativeResponse converts an engine result to its JSON representation.
+func NewRotativeResponse(r calc.RotativeResult) RotativeResponse {
+	return RotativeResponse{
+		Principal:       r.Principal,
+		Interest:        r.Interest,
+		IOF:             r.IOF,
+		LateFee:         r.LateFee,
+		LateInterest:    r.LateInterest,
+		Charges:         r.Charges,
+		Total:           r.Total,
+		Days:            r.Days,
+		ChargedDays:     r.ChargedDays,
+		ChargeCapped:    r.ChargeCapped,
+		RoundingResidue: r.RoundingResidue,
+		Override:        newOverride(r.Override),
+	}
+}
+
+// NewInstallmentPlanResponse converts an installment plan to its JSON representation.
+func NewInstallmentPlanResponse(p domain.InstallmentPlan) InstallmentPlanResponse {
+	resp := InstallmentPlanResponse{
+		TotalAmount:     p.TotalAmount,
+		TotalIOF:        p.TotalIOF,
+		TotalInterest:   p.TotalInterest,
+		TotalWithIOF:    p.TotalWithIOF,
+		GraceInterest:   p.GraceInterest,
+		RoundingResidue: p.RoundingResidue,
+		IOFFinanced:     p.IOFFinanced,
+		Funding:         string(p.Funding),
+		Installments:    make([]InstallmentResponse, len(p.Installments)),
+		Override:        newOverride(p.Override),
+	}
+	for i, inst := range p.Installments {
+		resp.Installments[i] = InstallmentResponse{
+			Number:    inst.Number,
+			DueDate:   Date{inst.DueDate},
+			Principal: inst.Principal,
+			Interest:  inst.Interest,
+			IOF:       inst.IOF,
+			Amount:    inst.Amount,
+		}
+	}
+	return resp
+}
+
+// NewPaymentResponse converts an amortization result to its JSON representation.
+func NewPaymentResponse(a calc.AmortizationResult) PaymentResponse {
+	return PaymentResponse{
+		PaidIOF:          a.PaidIOF,
+		PaidInterest:     a.PaidInterest,
+		PaidLateInterest: a.PaidLateInterest,
+		PaidLateFee:      a.PaidLateFee,
+		PaidPrincipal:    a.PaidPrincipal,
+		Remaining:        a.Remaining,
+	}
+}
+
+func newOverride(ref *domain.OverrideRef) *Override {
+	if ref == nil {
+		return nil
+	}
+	o := &Override{
+		AccountID:     ref.AccountID,
+		ReasonCode:    ref.ReasonCode,
+		EffectiveFrom: Date{ref.EffectiveFrom},
+	}
+	if !ref.EffectiveUntil.IsZero() {
+		o.EffectiveUntil = &Date{ref.EffectiveUntil}
+	}
+	return o
+}
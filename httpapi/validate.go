@@ -0,0 +1,108 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// The engine leaves input validation to the caller; Validate is that caller
+// for the HTTP API and for any tool reusing these request types.
+
+func (r RotativeRequest) Validate() error {
+	if r.Principal < 0 {
+		return errors.New("principal must not be negative")
+	}
+	if r.Principal > calc.MaxAmount {
+		return fmt.Errorf("principal must not exceed %d", calc.MaxAmount)
+	}
+	if r.StartDate.IsZero() || r.CalcDate.IsZero() {
+		return errors.New("start_date and calc_date are required")
+	}
+	if r.CalcDate.Before(r.StartDate.Time) {
+		return errors.New("calc_date must not be before start_date")
+	}
+	return nil
+}
+
+// Balance returns the engine input described by the request.
+func (r RotativeRequest) Balance() domain.RotativeBalance {
+	return domain.RotativeBalance{
+		AccountID: r.AccountID,
+		Principal: r.Principal,
+		StartDate: r.StartDate.Time,
+	}
+}
+
+func (r InstallmentRequest) Validate() error {
+	if r.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	if r.Amount > calc.MaxAmount {
+		return fmt.Errorf("amount must not exceed %d", calc.MaxAmount)
+	}
+	if r.Installments < 1 || r.Installments > MaxInstallments {
+		return fmt.Errorf("installments must be between 1 and %d", MaxInstallments)
+	}
+	if r.PurchaseDate.IsZero() || r.FirstDueDate.IsZero() {
+		return errors.New("purchase_date and first_due_date are required")
+	}
+	if !r.FirstDueDate.After(r.PurchaseDate.Time) {
+		return errors.New("first_due_date must be after purchase_date")
+	}
+	return nil
+}
+
+func (r PaymentRequest) Validate() error {
+	for _, f := range []struct {
+		name  string
+		value domain.Money
+	}{
+		{"total", r.Total},
+		{"iof", r.IOF},
+		{"interest", r.Interest},
+		{"late_interest", r.LateInterest},
+		{"late_fee", r.LateFee},
+		{"principal", r.Principal},
+		{"payment", r.Payment},
+	} {
+		if f.value < 0 {
+			return fmt.Errorf("%s must not be negative", f.name)
+		}
+		if f.value > calc.MaxAmount {
+			return fmt.Errorf("%s must not exceed %d", f.name, calc.MaxAmount)
+		}
+	}
+	if r.Total != r.IOF+r.Interest+r.LateInterest+r.LateFee+r.Principal {
+		return errors.New("total must equal the sum of iof, interest, late_interest, late_fee and principal")
+	}
+	return nil
+}
+
+// RotativeResult returns the buckets of the request as an engine result.
+func (r PaymentRequest) RotativeResult() calc.RotativeResult {
+	return calc.RotativeResult{
+		Principal:    r.Principal,
+		Interest:     r.Interest,
+		IOF:          r.IOF,
+		LateFee:      r.LateFee,
+		LateInterest: r.LateInterest,
+		Charges:      r.IOF + r.Interest + r.LateInterest + r.LateFee,
+		Total:        r.Total,
+	}
+}
+
+func (r IOFRequest) Validate() error {
+	if r.Amount < 0 {
+		return errors.New("amount must not be negative")
+	}
+	if r.Amount > calc.MaxAmount {
+		return fmt.Errorf("amount must not exceed %d", calc.MaxAmount)
+	}
+	if r.Days < 0 {
+		return errors.New("days must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package iofreport
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Period is a decendial IOF collection period: days 1-10 (Decendio 1),
+// 11-20 (2) and 21 to month end (3).
+type Period struct {
+	Year     int
+	Month    time.Month
+	Decendio int
+}
+
+// PeriodOf returns the period containing t.
+func PeriodOf(t time.Time) Period {
+	return Period{Year: t.Year(), Month: t.Month(), Decendio: min((t.Day()-1)/10+1, 3)}
+}
+
+// Start returns the first day of the period.
+func (p Period) Start() time.Time {
+	return time.Date(p.Year, p.Month, (p.Decendio-1)*10+1, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the last day of the period.
+func (p Period) End() time.Time {
+	if p.Decendio < 3 {
+		return time.Date(p.Year, p.Month, p.Decendio*10, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(p.Year, p.Month+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// DueDate returns when the IOF of the period must be paid: the third business
+// day after the period ends. Only weekends are skipped; holidays are not known
+// to the engine.
+func (p Period) DueDate() time.Time {
+	d := p.End()
+	for n := 0; n < 3; {
+		d = d.AddDate(0, 0, 1)
+		if wd := d.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			n++
+		}
+	}
+	return d
+}
+
+// Compare orders periods chronologically.
+func (p Period) Compare(o Period) int {
+	return cmp.Or(cmp.Compare(p.Year, o.Year), cmp.Compare(p.Month, o.Month), cmp.Compare(p.Decendio, o.Decendio))
+}
+
+// String formats the period as YYYY-MM-D<n>, e.g. 2024-01-D2.
+func (p Period) String() string {
+	return fmt.Sprintf("%04d-%02d-D%d", p.Year, int(p.Month), p.Decendio)
+}
+
+func (p Period) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
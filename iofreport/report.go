@@ -0,0 +1,202 @@
+// Package iofreport aggregates the IOF charged by the engine for collection
+// to the Receita Federal: by IOF type, taxpayer profile and decendial
+// collection period, with CSV and JSON export for the tax team.
+package iofreport
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// Kind is the IOF type collected.
+type Kind string
+
+const (
+	KindDaily         Kind = "daily"         // credit IOF, daily rate
+	KindAdditional    Kind = "additional"    // credit IOF, additional rate
+	KindInternational Kind = "international" // exchange IOF on international purchases
+)
+
+// Profile is the taxpayer profile of the cardholder.
+type Profile string
+
+const (
+	ProfilePF Profile = "pf" // pessoa fisica
+	ProfilePJ Profile = "pj" // pessoa juridica
+)
+
+// Record is an amount of IOF charged on Date.
+type Record struct {
+	Date      time.Time
+	AccountID string
+	Profile   Profile
+	Kind      Kind
+	Amount    domain.Money
+}
+
+// Collector accumulates IOF records. The zero value is ready to use; it is
+// not safe for concurrent use.
+type Collector struct {
+	records []Record
+}
+
+// Add records an amount; zero amounts are ignored.
+func (c *Collector) Add(r Record) {
+	if r.Amount != 0 {
+		c.records = append(c.records, r)
+	}
+}
+
+// AddCredit records the daily and additional credit IOF of principal over days.
+func (c *Collector) AddCredit(accountID string, profile Profile, date time.Time, principal domain.Money, days int, cfg config.IOFConfig) {
+	b := calc.CalculateIOFBreakdown(principal, days, cfg)
+	c.Add(Record{Date: date, AccountID: accountID, Profile: profile, Kind: KindDaily, Amount: b.Daily})
+	c.Add(Record{Date: date, AccountID: accountID, Profile: profile, Kind: KindAdditional, Amount: b.Additional})
+}
+
+// AddRotative records the IOF of a rotative result charged on date. The
+// amount is result.IOF as charged; cfg only splits it into the additional
+// IOF on the principal and the daily IOF, the rest.
+func (c *Collector) AddRotative(accountID string, profile Profile, date time.Time, result calc.RotativeResult, cfg config.IOFConfig) {
+	additional := min(calc.CalculateIOFBreakdown(result.Principal, result.ChargedDays, cfg).Additional, result.IOF)
+	c.Add(Record{Date: date, AccountID: accountID, Profile: profile, Kind: KindDaily, Amount: result.IOF - additional})
+	c.Add(Record{Date: date, AccountID: accountID, Profile: profile, Kind: KindAdditional, Amount: additional})
+}
+
+// AddInstallmentPlan records the IOF of every parcel of a plan contracted on
+// purchaseDate, collected on that date. The amounts are the parcels' IOF as
+// charged: with the IOF financed it is levied on the grossed-up amortization
+// (principal + IOF), not on the purchase principal.
+func (c *Collector) AddInstallmentPlan(accountID string, profile Profile, purchaseDate time.Time, plan domain.InstallmentPlan, cfg config.IOFConfig) {
+	for _, inst := range plan.Installments {
+		if inst.IOF == 0 {
+			continue
+		}
+		base := inst.Principal
+		if plan.IOFFinanced {
+			base += inst.IOF
+		}
+		days := int(inst.DueDate.Sub(purchaseDate).Hours() / 24)
+		additional := min(calc.CalculateIOFBreakdown(base, days, cfg).Additional, inst.IOF)
+		c.Add(Record{Date: purchaseDate, AccountID: accountID, Profile: profile, Kind: KindDaily, Amount: inst.IOF - additional})
+		c.Add(Record{Date: purchaseDate, AccountID: accountID, Profile: profile, Kind: KindAdditional, Amount: additional})
+	}
+}
+
+// AddInternational records the exchange IOF of an international purchase.
+func (c *Collector) AddInternational(accountID string, profile Profile, date time.Time, amount domain.Money, cfg config.InternationalIOFConfig) {
+	c.Add(Record{Date: date, AccountID: accountID, Profile: profile, Kind: KindInternational, Amount: calc.CalculateInternationalIOF(amount, cfg)})
+}
+
+// Line is the total of one period, kind and profile.
+type Line struct {
+	Period  Period       `json:"period"`
+	DueDate time.Time    `json:"due_date"`
+	Kind    Kind         `json:"kind"`
+	Profile Profile      `json:"profile"`
+	Count   int          `json:"count"`
+	Amount  domain.Money `json:"amount"`
+}
+
+// PeriodTotal is the IOF to be paid for one decendial period.
+type PeriodTotal struct {
+	Period  Period       `json:"period"`
+	DueDate time.Time    `json:"due_date"`
+	Amount  domain.Money `json:"amount"`
+}
+
+// Report is the aggregated IOF, ordered by period, kind and profile.
+type Report struct {
+	Lines   []Line        `json:"lines"`
+	Periods []PeriodTotal `json:"periods"`
+	Total   domain.Money  `json:"total"`
+}
+
+// Report aggregates the records collected so far.
+func (c *Collector) Report() Report {
+	type key struct {
+		period  Period
+		kind    Kind
+		profile Profile
+	}
+	lines := make(map[key]*Line)
+	periods := make(map[Period]*PeriodTotal)
+
+	var r Report
+	for _, rec := range c.records {
+		p := PeriodOf(rec.Date)
+		k := key{p, rec.Kind, rec.Profile}
+		l, ok := lines[k]
+		if !ok {
+			l = &Line{Period: p, DueDate: p.DueDate(), Kind: rec.Kind, Profile: rec.Profile}
+			lines[k] = l
+		}
+		l.Count++
+		l.Amount += rec.Amount
+
+		pt, ok := periods[p]
+		if !ok {
+			pt = &PeriodTotal{Period: p, DueDate: p.DueDate()}
+			periods[p] = pt
+		}
+		pt.Amount += rec.Amount
+		r.Total += rec.Amount
+	}
+
+	for _, l := range lines {
+		r.Lines = append(r.Lines, *l)
+	}
+	for _, pt := range periods {
+		r.Periods = append(r.Periods, *pt)
+	}
+	slices.SortFunc(r.Lines, func(a, b Line) int {
+		return cmp.Or(a.Period.Compare(b.Period), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Profile, b.Profile))
+	})
+	slices.SortFunc(r.Periods, func(a, b PeriodTotal) int { return a.Period.Compare(b.Period) })
+	return r
+}
+
+// WriteCSV writes one row per line with a header.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"period", "period_start", "period_end", "due_date", "kind", "profile", "count", "amount"}); err != nil {
+		return err
+	}
+	for _, l := range r.Lines {
+		row := []string{
+			l.Period.String(),
+			l.Period.Start().Format(time.DateOnly),
+			l.Period.End().Format(time.DateOnly),
+			l.DueDate.Format(time.DateOnly),
+			string(l.Kind),
+			string(l.Profile),
+			strconv.Itoa(l.Count),
+			strconv.FormatInt(int64(l.Amount), 10),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report as indented JSON. Amounts are in centavos.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("iofreport: encode: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package iofreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func utcDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPeriod(t *testing.T) {
+	cases := []struct {
+		date            time.Time
+		want            string
+		start, end, due time.Time
+	}{
+		// Jan 10 2024 is a Wednesday: due Mon Jan 15.
+		{utcDate(2024, 1, 1), "2024-01-D1", utcDate(2024, 1, 1), utcDate(2024, 1, 10), utcDate(2024, 1, 15)},
+		{utcDate(2024, 1, 20), "2024-01-D2", utcDate(2024, 1, 11), utcDate(2024, 1, 20), utcDate(2024, 1, 24)},
+		// Feb 29 2024 is a Thursday: due Tue Mar 5.
+		{utcDate(2024, 2, 29), "2024-02-D3", utcDate(2024, 2, 21), utcDate(2024, 2, 29), utcDate(2024, 3, 5)},
+	}
+	for _, tc := range cases {
+		p := PeriodOf(tc.date)
+		if p.String() != tc.want || !p.Start().Equal(tc.start) || !p.End().Equal(tc.end) || !p.DueDate().Equal(tc.due) {
+			t.Fatalf("%v: got %s %v..%v due %v", tc.date, p, p.Start(), p.End(), p.DueDate())
+		}
+	}
+}
+
+func TestCollector_GroupsByPeriodKindAndProfile(t *testing.T) {
+	iofCfg := config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800}
+	var c Collector
+
+	c.AddCredit("acc-1", ProfilePF, utcDate(2024, 1, 5), 100_000, 30, iofCfg)
+	c.AddCredit("acc-2", ProfilePF, utcDate(2024, 1, 9), 100_000, 30, iofCfg)
+	c.AddCredit("acc-3", ProfilePJ, utcDate(2024, 1, 9), 100_000, 30, iofCfg)
+	c.AddInternational("acc-1", ProfilePF, utcDate(2024, 1, 15), 10_000, config.InternationalIOFConfig{Rate: 35_000})
+
+	r := c.Report()
+
+	// D1: daily/pf, daily/pj, additional/pf, additional/pj; D2: international/pf.
+	if len(r.Lines) != 5 || len(r.Periods) != 2 {
+		t.Fatalf("expected 5 lines in 2 periods, got %d / %d", len(r.Lines), len(r.Periods))
+	}
+	first := r.Lines[0]
+	if first.Kind != KindAdditional || first.Profile != ProfilePF || first.Count != 2 || first.Amount != 760 {
+		t.Fatalf("unexpected first line %+v", first)
+	}
+	// 100000 × 0.0082% × 30 = 246 each.
+	if r.Lines[2].Kind != KindDaily || r.Lines[2].Amount != 492 {
+		t.Fatalf("unexpected daily line %+v", r.Lines[2])
+	}
+	if r.Periods[1].Amount != 350 || r.Total != r.Periods[0].Amount+r.Periods[1].Amount {
+		t.Fatalf("unexpected period totals %+v (total %d)", r.Periods, r.Total)
+	}
+}
+
+func TestCollector_RotativeMatchesEngineIOF(t *testing.T) {
+	iofCfg := config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800}
+	result := calc.CalculateRotative(
+		domain.RotativeBalance{Principal: 123_457, StartDate: utcDate(2024, 1, 10)},
+		utcDate(2024, 2, 9), iofCfg, config.InterestConfig{MonthlyRate: 120_000},
+		config.LateFeeConfig{Rate: 20_000}, config.LateInterestConfig{MonthlyRate: 10_000},
+		config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 1_000_000})
+
+	var c Collector
+	c.AddRotative("acc-1", ProfilePF, utcDate(2024, 2, 9), result, iofCfg)
+	if got := c.Report().Total; got != result.IOF {
+		t.Fatalf("expected collected IOF %d, got %d", result.IOF, got)
+	}
+
+	// A config other than the pricing one still reports the IOF charged.
+	changed := config.IOFConfig{DailyRate: 41, AdditionalRate: 9_000, MaxAnnualRate: 40_800}
+	var other Collector
+	other.AddRotative("acc-1", ProfilePF, utcDate(2024, 2, 9), result, changed)
+	r := other.Report()
+	if r.Total != result.IOF {
+		t.Fatalf("expected collected IOF %d with another config, got %d", result.IOF, r.Total)
+	}
+	for _, l := range r.Lines {
+		if l.Amount < 0 {
+			t.Fatalf("negative line %+v", l)
+		}
+	}
+}
+
+func TestCollector_InstallmentPlanMatchesChargedIOF(t *testing.T) {
+	iofCfg := config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800}
+	for _, financed := range []bool{false, true} {
+		instCfg := config.InstallmentConfig{MonthlyRate: 19_900, FinanceIOF: financed}
+		plan := calc.CalculateInstallmentPlan(1_000_000, 6, utcDate(2024, 1, 5), utcDate(2024, 2, 5), iofCfg, instCfg)
+
+		var c Collector
+		c.AddInstallmentPlan("acc-1", ProfilePF, utcDate(2024, 1, 5), plan, iofCfg)
+		if got := c.Report().Total; got != plan.TotalIOF {
+			t.Fatalf("financed=%v: expected collected IOF %d, got %d", financed, plan.TotalIOF, got)
+		}
+	}
+}
+
+func TestReport_Export(t *testing.T) {
+	var c Collector
+	c.AddInternational("acc-1", ProfilePF, utcDate(2024, 1, 15), 10_000, config.InternationalIOFConfig{Rate: 35_000})
+	r := c.Report()
+
+	var csvOut bytes.Buffer
+	if err := r.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	want := "period,period_start,period_end,due_date,kind,profile,count,amount\n" +
+		"2024-01-D2,2024-01-11,2024-01-20,2024-01-24,international,pf,1,350\n"
+	if csvOut.String() != want {
+		t.Fatalf("unexpected csv:\n%s", csvOut.String())
+	}
+
+	var jsonOut bytes.Buffer
+	if err := r.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var decoded struct {
+		Lines []struct {
+			Period string `json:"period"`
+			Amount int64  `json:"amount"`
+		} `json:"lines"`
+		Total int64 `json:"total"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.Lines[0].Period != "2024-01-D2" || decoded.Total != 350 || !strings.Contains(jsonOut.String(), `"due_date"`) {
+		t.Fatalf("unexpected json:\n%s", jsonOut.String())
+	}
+}
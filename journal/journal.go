@@ -0,0 +1,120 @@
+// Package journal converts engine results into double-entry journal entries
+// mapped to a COSIF chart of accounts (config.ChartOfAccounts). Every entry
+// it builds is balanced: total debits equal total credits.
+package journal
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// Entry is a journal entry (lancamento contabil).
+type Entry struct {
+	Date        time.Time
+	Description string
+	Lines       []Line
+}
+
+// Line debits or credits one account. Exactly one of Debit and Credit is set.
+type Line struct {
+	Account string
+	Debit   domain.Money
+	Credit  domain.Money
+	Memo    string
+}
+
+// Totals returns the sum of debits and credits of the entry.
+func (e Entry) Totals() (debit, credit domain.Money) {
+	for _, l := range e.Lines {
+		debit += l.Debit
+		credit += l.Credit
+	}
+	return debit, credit
+}
+
+// Balanced reports whether debits equal credits.
+func (e Entry) Balanced() bool {
+	debit, credit := e.Totals()
+	return debit == credit
+}
+
+// Rotative posts the charges of a rotative result: the receivable grows by
+// Charges against interest, late interest and late fee revenue and the IOF
+// owed to the Receita. The principal is already a receivable.
+func Rotative(result calc.RotativeResult, date time.Time, chart config.ChartOfAccounts) Entry {
+	e := Entry{Date: date, Description: "Encargos do rotativo"}
+	e.debit(chart.Receivables, result.Charges, "encargos")
+	e.credit(chart.InterestRevenue, result.Interest, "juros rotativo")
+	e.credit(chart.LateInterestRevenue, result.LateInterest, "juros de mora")
+	e.credit(chart.LateFeeRevenue, result.LateFee, "multa")
+	e.credit(chart.IOFPayable, result.IOF, "IOF")
+	return e
+}
+
+// Accrual posts one day of rotative accrual (calc.AccrueRotative) for revenue
+// recognition. Negative interest increments, which only happen once the
+// charge cap binds, are posted as reversals.
+func Accrual(a calc.DailyAccrual, chart config.ChartOfAccounts) Entry {
+	e := Entry{Date: a.Date, Description: "Apropriacao diaria do rotativo"}
+	e.debit(chart.Receivables, a.Charges, "encargos do dia")
+	e.credit(chart.InterestRevenue, a.Interest, "juros rotativo")
+	e.credit(chart.LateInterestRevenue, a.LateInterest, "juros de mora")
+	e.credit(chart.LateFeeRevenue, a.LateFee, "multa")
+	e.credit(chart.IOFPayable, a.IOF, "IOF")
+	return e
+}
+
+// Payment posts a payment applied by calc.ApplyPayment: cash in, receivable out.
+func Payment(result calc.AmortizationResult, date time.Time, chart config.ChartOfAccounts) Entry {
+	paid := result.PaidIOF + result.PaidInterest + result.PaidLateInterest + result.PaidLateFee + result.PaidPrincipal
+	e := Entry{Date: date, Description: "Pagamento de fatura"}
+	e.debit(chart.Cash, paid, "pagamento")
+	e.credit(chart.Receivables, paid, "baixa do saldo")
+	return e
+}
+
+// InstallmentPlan posts a new plan: the principal owed to the merchant, the
+// IOF owed to the Receita and the interest still to be earned all become
+// receivables. Interest is recognized as parcels are billed (Installment).
+// Merchant-funded plans carry only the principal.
+func InstallmentPlan(plan domain.InstallmentPlan, date time.Time, chart config.ChartOfAccounts) Entry {
+	e := Entry{Date: date, Description: "Contratacao de parcelamento"}
+	e.debit(chart.Receivables, plan.TotalWithIOF, "parcelamento")
+	e.credit(chart.MerchantPayable, plan.TotalAmount, "valor da compra")
+	e.credit(chart.UnearnedInterest, plan.TotalInterest, "juros a apropriar")
+	e.credit(chart.IOFPayable, plan.TotalIOF, "IOF")
+	return e
+}
+
+// Installment recognizes the interest of a billed parcel.
+func Installment(inst domain.Installment, chart config.ChartOfAccounts) Entry {
+	e := Entry{Date: inst.DueDate, Description: "Apropriacao de juros do parcelamento"}
+	e.debit(chart.UnearnedInterest, inst.Interest, "juros a apropriar")
+	e.credit(chart.InterestRevenue, inst.Interest, "juros parcelamento")
+	return e
+}
+
+// debit adds a debit line; negative amounts become a credit on the same
+// account and zero amounts are skipped.
+func (e *Entry) debit(account string, amount domain.Money, memo string) {
+	switch {
+	case amount > 0:
+		e.Lines = append(e.Lines, Line{Account: account, Debit: amount, Memo: memo})
+	case amount < 0:
+		e.Lines = append(e.Lines, Line{Account: account, Credit: -amount, Memo: "estorno " + memo})
+	}
+}
+
+// credit adds a credit line; negative amounts become a debit on the same
+// account and zero amounts are skipped.
+func (e *Entry) credit(account string, amount domain.Money, memo string) {
+	switch {
+	case amount > 0:
+		e.Lines = append(e.Lines, Line{Account: account, Credit: amount, Memo: memo})
+	case amount < 0:
+		e.Lines = append(e.Lines, Line{Account: account, Debit: -amount, Memo: "estorno " + memo})
+	}
+}
@@ -0,0 +1,116 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func testChart() config.ChartOfAccounts {
+	return config.ChartOfAccounts{
+		Cash:                "1.1.2.10.00",
+		Receivables:         "1.6.1.20.00",
+		UnearnedInterest:    "1.6.1.99.00",
+		MerchantPayable:     "4.9.9.92.00",
+		IOFPayable:          "4.9.4.20.00",
+		InterestRevenue:     "7.1.1.05.00",
+		LateInterestRevenue: "7.1.1.06.00",
+		LateFeeRevenue:      "7.1.9.99.00",
+	}
+}
+
+func utcDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func amountOn(e Entry, account string) (debit, credit domain.Money) {
+	for _, l := range e.Lines {
+		if l.Account == account {
+			debit += l.Debit
+			credit += l.Credit
+		}
+	}
+	return debit, credit
+}
+
+func TestRotative_BalancedByBucket(t *testing.T) {
+	chart := testChart()
+	result := calc.RotativeResult{Principal: 100_000, Interest: 12_000, IOF: 1_200, LateFee: 2_000, LateInterest: 1_000, Charges: 16_200, Total: 116_200}
+
+	e := Rotative(result, utcDate(2024, 2, 10), chart)
+
+	if !e.Balanced() {
+		t.Fatalf("entry not balanced: %+v", e.Lines)
+	}
+	if d, _ := amountOn(e, chart.Receivables); d != 16_200 {
+		t.Fatalf("expected receivables debit 16200, got %d", d)
+	}
+	checks := map[string]domain.Money{
+		chart.InterestRevenue:     12_000,
+		chart.LateInterestRevenue: 1_000,
+		chart.LateFeeRevenue:      2_000,
+		chart.IOFPayable:          1_200,
+	}
+	for account, want := range checks {
+		if _, c := amountOn(e, account); c != want {
+			t.Fatalf("%s: expected credit %d, got %d", account, want, c)
+		}
+	}
+}
+
+func TestAccrual_ReversalStaysBalanced(t *testing.T) {
+	chart := testChart()
+	// Capped day: interest gives way to late interest.
+	e := Accrual(calc.DailyAccrual{Date: utcDate(2024, 2, 1), Interest: -30, LateInterest: 33, IOF: 2, Charges: 5}, chart)
+
+	if !e.Balanced() {
+		t.Fatalf("entry not balanced: %+v", e.Lines)
+	}
+	if d, _ := amountOn(e, chart.InterestRevenue); d != 30 {
+		t.Fatalf("expected interest reversal debit 30, got %d", d)
+	}
+}
+
+func TestPayment_CashAgainstReceivables(t *testing.T) {
+	chart := testChart()
+	amort := calc.ApplyPayment(116_200, 1_200, 12_000, 1_000, 2_000, 100_000, 50_000)
+
+	e := Payment(amort, utcDate(2024, 2, 20), chart)
+
+	if !e.Balanced() {
+		t.Fatalf("entry not balanced")
+	}
+	if d, _ := amountOn(e, chart.Cash); d != 50_000 {
+		t.Fatalf("expected cash debit 50000, got %d", d)
+	}
+}
+
+func TestInstallmentPlan_InterestRecognizedOnBilling(t *testing.T) {
+	chart := testChart()
+	iofCfg := config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800}
+	plan := calc.CalculateInstallmentPlan(100_000, 6, utcDate(2024, 1, 5), utcDate(2024, 2, 10), iofCfg, config.InstallmentConfig{MonthlyRate: 19_900})
+
+	e := InstallmentPlan(plan, utcDate(2024, 1, 5), chart)
+	if !e.Balanced() {
+		t.Fatalf("plan entry not balanced")
+	}
+	if _, c := amountOn(e, chart.MerchantPayable); c != 100_000 {
+		t.Fatalf("expected merchant payable 100000, got %d", c)
+	}
+
+	var recognized domain.Money
+	for _, inst := range plan.Installments {
+		ie := Installment(inst, chart)
+		if !ie.Balanced() {
+			t.Fatalf("installment %d entry not balanced", inst.Number)
+		}
+		_, c := amountOn(ie, chart.InterestRevenue)
+		recognized += c
+	}
+	if _, unearned := amountOn(e, chart.UnearnedInterest); recognized != unearned {
+		t.Fatalf("recognized interest %d differs from unearned %d", recognized, unearned)
+	}
+}
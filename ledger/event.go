@@ -0,0 +1,66 @@
+// Package ledger is an append-only, event-sourced account ledger. Events are
+// the only source of truth: amounts that depend on the config (installment
+// plans, IOF, minimum payments, rotative charges) are priced by Ledger.Append
+// and stored in the event, and Replay rebuilds balances from them, so a later
+// config change never re-prices history.
+package ledger
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// EventKind identifies a ledger event.
+type EventKind string
+
+const (
+	// EventPurchase is a purchase in Installments (at least 1). With more
+	// than one it opens an installment plan, starting on FirstDueDate, whose
+	// parcels are billed by EventInstallmentPosted; otherwise Amount (plus
+	// international IOF) goes to the open cycle.
+	EventPurchase EventKind = "purchase"
+	// EventPayment pays the oldest closed invoices first; any excess is a
+	// credit on the open cycle.
+	EventPayment EventKind = "payment"
+	// EventClosing closes the open cycle into invoice InvoiceID due on DueDate.
+	EventClosing EventKind = "closing"
+	// EventChargePosted computes the rotative charges of invoice InvoiceID at
+	// Date and carries its balance plus charges into the open cycle.
+	EventChargePosted EventKind = "charge_posted"
+	// EventInstallmentPosted bills parcel InstallmentNumber of the plan opened
+	// by purchase PlanSeq into the open cycle.
+	EventInstallmentPosted EventKind = "installment_posted"
+)
+
+// Event is an immutable ledger entry. Seq is assigned by the Store on append,
+// starting at 1 per account. Only the fields of its Kind are set.
+type Event struct {
+	Seq       int64     `json:"seq"`
+	AccountID string    `json:"account_id"`
+	Kind      EventKind `json:"kind"`
+	Date      time.Time `json:"date"`
+
+	// Purchase and payment.
+	Amount        domain.Money `json:"amount,omitempty"`
+	Description   string       `json:"description,omitempty"`
+	International bool         `json:"international,omitempty"`
+	Installments  int          `json:"installments,omitempty"`
+	FirstDueDate  time.Time    `json:"first_due_date,omitzero"`
+
+	// Closing and charge posted.
+	InvoiceID string    `json:"invoice_id,omitempty"`
+	DueDate   time.Time `json:"due_date,omitzero"`
+
+	// Installment posted.
+	PlanSeq           int64 `json:"plan_seq,omitempty"`
+	InstallmentNumber int   `json:"installment_number,omitempty"`
+
+	// Priced by Ledger.Append with its config and replayed as stored.
+	Plan           *domain.InstallmentPlan `json:"plan,omitempty"`            // purchase in installments
+	MonthlyRate    domain.Rate             `json:"monthly_rate,omitempty"`    // contract rate of Plan
+	IOF            domain.Money            `json:"iof,omitempty"`             // international purchase
+	MinimumPayment domain.Money            `json:"minimum_payment,omitempty"` // closing
+	Rotative       *calc.RotativeResult    `json:"rotative,omitempty"`        // charge posted
+}
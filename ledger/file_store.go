@@ -0,0 +1,84 @@
+package ledger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// FileStore is a Store backed by a JSON Lines file: one event per line,
+// appended and synced on every Append. Events are also kept in memory, so the
+// file is only read when the store is opened.
+type FileStore struct {
+	mu   sync.Mutex
+	file *os.File
+	mem  *MemoryStore
+}
+
+// OpenFileStore opens (or creates) the event file at path and loads its events.
+func OpenFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("ledger: open %s: %w", path, err)
+	}
+
+	s := &FileStore{file: f, mem: NewMemoryStore()}
+	if err := s.load(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("ledger: load %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *FileStore) load(r io.Reader) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var ev Event
+		err := dec.Decode(&ev)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		stored, _ := s.mem.Append(ev)
+		if stored.Seq != ev.Seq {
+			return fmt.Errorf("account %s: expected seq %d, found %d", ev.AccountID, stored.Seq, ev.Seq)
+		}
+	}
+}
+
+func (s *FileStore) Append(ev Event) (Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, _ := s.mem.Events(ev.AccountID)
+	ev.Seq = int64(len(events)) + 1
+
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return Event{}, fmt.Errorf("ledger: encode event: %w", err)
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return Event{}, fmt.Errorf("ledger: write event: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return Event{}, fmt.Errorf("ledger: sync: %w", err)
+	}
+	return s.mem.Append(ev)
+}
+
+func (s *FileStore) Events(accountID string) ([]Event, error) {
+	return s.mem.Events(accountID)
+}
+
+// Close closes the underlying file.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
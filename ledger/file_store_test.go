@@ -0,0 +1,47 @@
+package ledger
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore_PersistsAndReplays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	cfg := testEngineConfig()
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	want := appendAll(t, New(store, cfg), cycleEvents())
+	if err := store.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	events, err := reopened.Events("acc-1")
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if len(events) != len(cycleEvents()) || events[len(events)-1].Seq != int64(len(events)) {
+		t.Fatalf("expected %d events in seq order, got %+v", len(cycleEvents()), events)
+	}
+
+	got, err := New(reopened, cfg).Account("acc-1")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if got.Balance != want.Balance || got.Invoices[0].Invoice.Status != want.Invoices[0].Invoice.Status {
+		t.Fatalf("replay differs: balance %d vs %d", got.Balance, want.Balance)
+	}
+
+	ev, err := reopened.Append(Event{AccountID: "acc-1", Kind: EventPayment, Date: utcDate(2024, 2, 20), Amount: 1_000})
+	if err != nil || ev.Seq != int64(len(cycleEvents())+1) {
+		t.Fatalf("expected next seq after reopen, got %d (%v)", ev.Seq, err)
+	}
+}
@@ -0,0 +1,60 @@
+package ledger
+
+import (
+	"sync"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+)
+
+// Ledger validates and appends events to a Store and replays accounts.
+type Ledger struct {
+	Store Store
+	// Config prices the events being appended; stored events keep the
+	// amounts they were priced with.
+	Config config.EngineConfig
+
+	mu sync.Mutex
+}
+
+func New(store Store, cfg config.EngineConfig) *Ledger {
+	return &Ledger{Store: store, Config: cfg}
+}
+
+// Append prices ev with Config and records it, with its priced amounts, after
+// checking that the account still replays with it, so illegal events (e.g.
+// paying an installed invoice) are never stored. It returns the stored event
+// with its Seq and the resulting account.
+func (l *Ledger) Append(ev Event) (Event, Account, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	events, err := l.Store.Events(ev.AccountID)
+	if err != nil {
+		return Event{}, Account{}, err
+	}
+
+	acc, err := Replay(ev.AccountID, events)
+	if err != nil {
+		return Event{}, Account{}, err
+	}
+	ev.Seq = int64(len(events)) + 1
+	if err := acc.apply(&ev, &l.Config); err != nil {
+		return Event{}, Account{}, err
+	}
+	acc.updateBalance()
+
+	stored, err := l.Store.Append(ev)
+	if err != nil {
+		return Event{}, Account{}, err
+	}
+	return stored, acc, nil
+}
+
+// Account replays the events of accountID.
+func (l *Ledger) Account(accountID string) (Account, error) {
+	events, err := l.Store.Events(accountID)
+	if err != nil {
+		return Account{}, err
+	}
+	return Replay(accountID, events)
+}
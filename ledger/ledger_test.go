@@ -0,0 +1,229 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func testEngineConfig() config.EngineConfig {
+	return config.EngineConfig{
+		IOF:              config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800},
+		Interest:         config.InterestConfig{MonthlyRate: 120_000},
+		LateFee:          config.LateFeeConfig{Rate: 20_000},
+		LateInterest:     config.LateInterestConfig{MonthlyRate: 10_000},
+		Rules:            config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 1_000_000},
+		InternationalIOF: config.InternationalIOFConfig{Rate: 35_000},
+		Installment:      config.InstallmentConfig{MonthlyRate: 19_900},
+		MinimumPayment:   config.MinimumPaymentConfig{Rate: 150_000, Floor: 5_000, IncludeInstallments: true, IncludeCharges: true},
+	}
+}
+
+func utcDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// cycleEvents is a month of activity: a purchase, an installment purchase
+// with its first parcel, the closing and a partial payment on the due date.
+func cycleEvents() []Event {
+	return []Event{
+		{AccountID: "acc-1", Kind: EventPurchase, Date: utcDate(2024, 1, 5), Amount: 100_000, Description: "MERCADO", Installments: 1},
+		{AccountID: "acc-1", Kind: EventPurchase, Date: utcDate(2024, 1, 10), Amount: 60_000, Description: "LOJA", Installments: 3, FirstDueDate: utcDate(2024, 2, 10)},
+		{AccountID: "acc-1", Kind: EventInstallmentPosted, Date: utcDate(2024, 1, 31), PlanSeq: 2, InstallmentNumber: 1},
+		{AccountID: "acc-1", Kind: EventClosing, Date: utcDate(2024, 1, 31), InvoiceID: "inv-01", DueDate: utcDate(2024, 2, 10)},
+		{AccountID: "acc-1", Kind: EventPayment, Date: utcDate(2024, 2, 10), Amount: 50_000},
+	}
+}
+
+func appendAll(t *testing.T, l *Ledger, events []Event) Account {
+	t.Helper()
+	var acc Account
+	for _, ev := range events {
+		var err error
+		if _, acc, err = l.Append(ev); err != nil {
+			t.Fatalf("append %s: %v", ev.Kind, err)
+		}
+	}
+	return acc
+}
+
+func TestLedger_ReplayCycle(t *testing.T) {
+	cfg := testEngineConfig()
+	l := New(NewMemoryStore(), cfg)
+	acc := appendAll(t, l, cycleEvents())
+
+	plan := calc.CalculateInstallmentPlan(60_000, 3, utcDate(2024, 1, 10), utcDate(2024, 2, 10), cfg.IOF, cfg.Installment)
+	if len(acc.Plans) != 1 || acc.Plans[0].Posted != 1 || acc.Plans[0].Plan.TotalWithIOF != plan.TotalWithIOF {
+		t.Fatalf("unexpected plan state: %+v", acc.Plans)
+	}
+
+	inv := acc.Invoices[0].Invoice
+	wantTotal := 100_000 + plan.Installments[0].Amount
+	if inv.TotalAmount != wantTotal || inv.PaidAmount != 50_000 {
+		t.Fatalf("expected invoice %d paid 50000, got %d paid %d", wantTotal, inv.TotalAmount, inv.PaidAmount)
+	}
+	if inv.Status != domain.InvoicePartiallyPaid || inv.MinimumPayment == 0 {
+		t.Fatalf("expected partially paid with a minimum, got %s min %d", inv.Status, inv.MinimumPayment)
+	}
+	if acc.Balance != wantTotal-50_000 {
+		t.Fatalf("expected balance %d, got %d", wantTotal-50_000, acc.Balance)
+	}
+}
+
+func TestLedger_ChargePostedCarriesRotative(t *testing.T) {
+	cfg := testEngineConfig()
+	l := New(NewMemoryStore(), cfg)
+	appendAll(t, l, cycleEvents())
+
+	_, acc, err := l.Append(Event{AccountID: "acc-1", Kind: EventChargePosted, Date: utcDate(2024, 3, 1), InvoiceID: "inv-01"})
+	if err != nil {
+		t.Fatalf("charge posted: %v", err)
+	}
+
+	st := acc.Invoices[0]
+	if st.Invoice.Status != domain.InvoiceInRotative || !st.CarriedOver {
+		t.Fatalf("expected carried in-rotative invoice, got %s carried=%v", st.Invoice.Status, st.CarriedOver)
+	}
+
+	remaining := st.Invoice.TotalAmount - st.Invoice.PaidAmount
+	want := calc.CalculateRotative(
+		domain.RotativeBalance{AccountID: "acc-1", Principal: remaining, StartDate: utcDate(2024, 2, 10)},
+		utcDate(2024, 3, 1), cfg.IOF, cfg.Interest, cfg.LateFee, cfg.LateInterest, cfg.Rules)
+	if acc.Rotative[0] != want {
+		t.Fatalf("expected %+v, got %+v", want, acc.Rotative[0])
+	}
+	if acc.Open.TotalAmount != want.Total || acc.Balance != want.Total {
+		t.Fatalf("expected open cycle and balance %d, got %d / %d", want.Total, acc.Open.TotalAmount, acc.Balance)
+	}
+
+	// Charges can be carried only once.
+	if _, _, err := l.Append(Event{AccountID: "acc-1", Kind: EventChargePosted, Date: utcDate(2024, 3, 2), InvoiceID: "inv-01"}); !errors.Is(err, ErrNoRotative) {
+		t.Fatalf("expected ErrNoRotative, got %v", err)
+	}
+}
+
+func TestLedger_ReplaysStoredPricesAfterConfigChange(t *testing.T) {
+	store := NewMemoryStore()
+	cfg := testEngineConfig()
+	before := appendAll(t, New(store, cfg), append(cycleEvents(),
+		Event{AccountID: "acc-1", Kind: EventChargePosted, Date: utcDate(2024, 3, 1), InvoiceID: "inv-01"}))
+
+	events, _ := store.Events("acc-1")
+	if events[1].Plan == nil || events[3].MinimumPayment == 0 || events[5].Rotative == nil {
+		t.Fatalf("expected priced plan, minimum payment and rotative in the stored events")
+	}
+
+	// New rates apply to new events only.
+	changed := cfg
+	changed.Installment.MonthlyRate = 29_900
+	changed.Interest.MonthlyRate = 150_000
+	changed.MinimumPayment.Rate = 200_000
+	l := New(store, changed)
+	after, err := l.Account("acc-1")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if in := after.PayoffInput(utcDate(2024, 3, 1)); len(in.Plans) != 1 || in.Plans[0].MonthlyRate != cfg.Installment.MonthlyRate {
+		t.Fatalf("expected the plan discounted at its contract rate %d, got %+v", cfg.Installment.MonthlyRate, in.Plans)
+	}
+	if after.Balance != before.Balance || after.Plans[0].Plan.TotalWithIOF != before.Plans[0].Plan.TotalWithIOF ||
+		after.Invoices[0].Invoice.MinimumPayment != before.Invoices[0].Invoice.MinimumPayment || after.Rotative[0] != before.Rotative[0] {
+		t.Fatalf("config change re-priced history: balance %d -> %d", before.Balance, after.Balance)
+	}
+
+	stored, _, err := l.Append(Event{AccountID: "acc-1", Kind: EventPurchase, Date: utcDate(2024, 3, 2), Amount: 60_000, Installments: 3, FirstDueDate: utcDate(2024, 4, 10)})
+	if err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	want := calc.CalculateInstallmentPlan(60_000, 3, utcDate(2024, 3, 2), utcDate(2024, 4, 10), changed.IOF, changed.Installment)
+	if stored.Plan.TotalWithIOF != want.TotalWithIOF {
+		t.Fatalf("expected the new purchase priced at the new rate: %d, got %d", want.TotalWithIOF, stored.Plan.TotalWithIOF)
+	}
+
+	// Events without their priced amounts do not replay.
+	events[1].MonthlyRate = 0
+	if _, err := Replay("acc-1", events); !errors.Is(err, ErrNotPriced) {
+		t.Fatalf("expected ErrNotPriced for a plan without its rate, got %v", err)
+	}
+	events[1].MonthlyRate = cfg.Installment.MonthlyRate
+	events[5].Rotative = nil
+	if _, err := Replay("acc-1", events); !errors.Is(err, ErrNotPriced) {
+		t.Fatalf("expected ErrNotPriced, got %v", err)
+	}
+}
+
+func TestLedger_RejectsInvalidEvents(t *testing.T) {
+	store := NewMemoryStore()
+	l := New(store, testEngineConfig())
+	appendAll(t, l, cycleEvents())
+
+	cases := []struct {
+		name string
+		ev   Event
+		want error
+	}{
+		{"unknown plan", Event{Kind: EventInstallmentPosted, Date: utcDate(2024, 2, 28), PlanSeq: 1, InstallmentNumber: 1}, ErrUnknownPlan},
+		{"parcel out of order", Event{Kind: EventInstallmentPosted, Date: utcDate(2024, 2, 28), PlanSeq: 2, InstallmentNumber: 3}, ErrInstallmentPosted},
+		{"not yet revolving", Event{Kind: EventChargePosted, Date: utcDate(2024, 2, 10), InvoiceID: "inv-01"}, ErrNoRotative},
+		{"unknown invoice", Event{Kind: EventChargePosted, Date: utcDate(2024, 3, 1), InvoiceID: "inv-99"}, ErrUnknownInvoice},
+		{"back in time", Event{Kind: EventPayment, Date: utcDate(2024, 1, 1), Amount: 100}, ErrOutOfOrder},
+		{"no installments", Event{Kind: EventPurchase, Date: utcDate(2024, 2, 28), Amount: 100}, ErrInvalidPurchase},
+		{"no first due date", Event{Kind: EventPurchase, Date: utcDate(2024, 2, 28), Amount: 100, Installments: 3}, ErrInvalidPurchase},
+	}
+	for _, tc := range cases {
+		tc.ev.AccountID = "acc-1"
+		if _, _, err := l.Append(tc.ev); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	events, _ := store.Events("acc-1")
+	if len(events) != len(cycleEvents()) {
+		t.Fatalf("rejected events must not be stored, got %d events", len(events))
+	}
+}
+
+func TestLedger_OverpaymentBecomesCredit(t *testing.T) {
+	l := New(NewMemoryStore(), testEngineConfig())
+	acc := appendAll(t, l, []Event{
+		{AccountID: "acc-2", Kind: EventPurchase, Date: utcDate(2024, 1, 5), Amount: 10_000, Installments: 1},
+		{AccountID: "acc-2", Kind: EventClosing, Date: utcDate(2024, 1, 31), InvoiceID: "inv-01", DueDate: utcDate(2024, 2, 10)},
+		{AccountID: "acc-2", Kind: EventPayment, Date: utcDate(2024, 2, 5), Amount: 15_000},
+		{AccountID: "acc-2", Kind: EventPurchase, Date: utcDate(2024, 2, 6), Amount: 3_000, Installments: 1},
+		{AccountID: "acc-2", Kind: EventClosing, Date: utcDate(2024, 2, 29), InvoiceID: "inv-02", DueDate: utcDate(2024, 3, 10)},
+	})
+
+	if acc.Invoices[0].Invoice.Status != domain.InvoicePaid || acc.Invoices[1].Invoice.Status != domain.InvoicePaid {
+		t.Fatalf("expected both invoices paid, got %s / %s", acc.Invoices[0].Invoice.Status, acc.Invoices[1].Invoice.Status)
+	}
+	if acc.Open.PaidAmount != 2_000 || acc.Balance != -2_000 {
+		t.Fatalf("expected 2000 credit, got paid %d balance %d", acc.Open.PaidAmount, acc.Balance)
+	}
+}
+
+func TestAccount_PayoffInput(t *testing.T) {
+	cfg := testEngineConfig()
+	l := New(NewMemoryStore(), cfg)
+	acc := appendAll(t, l, cycleEvents())
+
+	// After the due date the partially paid invoice revolves.
+	at := utcDate(2024, 2, 20)
+	in := acc.PayoffInput(at)
+
+	inv := acc.Invoices[0].Invoice
+	if in.Billed != 0 || len(in.Rotative) != 1 || in.Rotative[0].Principal != inv.TotalAmount-inv.PaidAmount {
+		t.Fatalf("expected the invoice balance as rotative, got %+v", in)
+	}
+	if len(in.Plans) != 1 || in.Plans[0].Posted != 1 || in.Plans[0].MonthlyRate != 19_900 {
+		t.Fatalf("expected the plan with 2 parcels left, got %+v", in.Plans)
+	}
+
+	q := calc.CalculatePayoff(in, at, cfg.IOF, cfg.Interest, cfg.LateFee, cfg.LateInterest, cfg.Rules, cfg.Payoff)
+	if q.Total <= acc.Balance {
+		t.Fatalf("payoff %d should include charges and future parcels beyond balance %d", q.Total, acc.Balance)
+	}
+}
@@ -0,0 +1,327 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+var (
+	// ErrUnknownPlan is returned when an installment is posted for a purchase
+	// that did not open an installment plan.
+	ErrUnknownPlan = errors.New("ledger: unknown installment plan")
+	// ErrInstallmentPosted is returned when a parcel is posted twice or out of order.
+	ErrInstallmentPosted = errors.New("ledger: installment out of order")
+	// ErrUnknownInvoice is returned when an event references a missing invoice.
+	ErrUnknownInvoice = errors.New("ledger: unknown invoice")
+	// ErrNoRotative is returned when charges are posted for an invoice that is
+	// not revolving (see calc.InvoiceRotativeBalance) or was already carried over.
+	ErrNoRotative = errors.New("ledger: invoice is not in rotative")
+	// ErrOutOfOrder is returned for events dated before the previous event.
+	ErrOutOfOrder = errors.New("ledger: event dated before previous event")
+	// ErrInvalidPurchase is returned for a purchase without a positive amount,
+	// with fewer than one installment or, in installments, without a first
+	// due date.
+	ErrInvalidPurchase = errors.New("ledger: invalid purchase")
+	// ErrNotPriced is returned when a replayed event lacks the amounts
+	// Ledger.Append prices and stores, or they do not match the account.
+	ErrNotPriced = errors.New("ledger: event not priced")
+)
+
+// Account is the state of an account rebuilt by Replay.
+type Account struct {
+	ID string
+	// Open is the current billing cycle (status open): its lines, TotalAmount
+	// and any credit from overpayments in PaidAmount.
+	Open     domain.Invoice
+	Invoices []InvoiceState
+	Plans    []PlanState
+	// Balance is what the account owes: the open cycle plus the remaining
+	// balance of invoices not carried over.
+	Balance domain.Money
+	// Rotative holds the rotative charges posted, in order.
+	Rotative []calc.RotativeResult
+
+	last time.Time // date of the last event applied
+}
+
+// InvoiceState is a closed invoice.
+type InvoiceState struct {
+	Invoice domain.Invoice
+	// CarriedOver is set once the revolving balance and its charges were
+	// moved to a later cycle by EventChargePosted.
+	CarriedOver bool
+}
+
+// PlanState is an installment plan opened by a purchase.
+type PlanState struct {
+	PurchaseSeq int64
+	Description string
+	Plan        domain.InstallmentPlan
+	MonthlyRate domain.Rate // contract rate the plan was priced at
+	Posted      int         // parcels billed so far
+}
+
+// Replay rebuilds the account from its events, in order, with the amounts
+// priced and stored in them by Ledger.Append.
+func Replay(accountID string, events []Event) (Account, error) {
+	acc := Account{ID: accountID}
+	for _, ev := range events {
+		if err := acc.apply(&ev, nil); err != nil {
+			return Account{}, err
+		}
+	}
+	acc.updateBalance()
+	return acc, nil
+}
+
+// apply applies ev to the account. With cfg (Ledger.Append) the amounts that
+// depend on the config are priced with it and stored in ev; without (Replay)
+// the ones stored in ev are used.
+func (a *Account) apply(ev *Event, cfg *config.EngineConfig) error {
+	if ev.Date.Before(a.last) {
+		return fmt.Errorf("ledger: event %d: %w", ev.Seq, ErrOutOfOrder)
+	}
+	a.last = ev.Date
+
+	if err := a.applyKind(ev, cfg); err != nil {
+		return fmt.Errorf("ledger: event %d (%s): %w", ev.Seq, ev.Kind, err)
+	}
+	return nil
+}
+
+func (a *Account) applyKind(ev *Event, cfg *config.EngineConfig) error {
+	// Let the due dates that passed before this event take effect.
+	for i := range a.Invoices {
+		inv, err := calc.ApplyInvoiceEvent(a.Invoices[i].Invoice, calc.InvoiceEvent{Kind: calc.InvoiceEventDayPassed, Date: ev.Date})
+		if err != nil {
+			return err
+		}
+		a.Invoices[i].Invoice = inv
+	}
+
+	switch ev.Kind {
+	case EventPurchase:
+		return a.purchase(ev, cfg)
+	case EventPayment:
+		return a.payment(*ev)
+	case EventClosing:
+		return a.closing(ev, cfg)
+	case EventChargePosted:
+		return a.chargePosted(ev, cfg)
+	case EventInstallmentPosted:
+		return a.installmentPosted(*ev)
+	default:
+		return fmt.Errorf("unknown event kind %q", ev.Kind)
+	}
+}
+
+func (a *Account) purchase(ev *Event, cfg *config.EngineConfig) error {
+	switch {
+	case ev.Amount <= 0:
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidPurchase)
+	case ev.Installments < 1:
+		return fmt.Errorf("%w: installments must be at least 1", ErrInvalidPurchase)
+	case ev.Installments > 1 && ev.FirstDueDate.IsZero():
+		return fmt.Errorf("%w: installments need a first due date", ErrInvalidPurchase)
+	}
+
+	if ev.Installments > 1 {
+		if cfg != nil {
+			plan := calc.CalculateInstallmentPlan(ev.Amount, ev.Installments, ev.Date, ev.FirstDueDate, cfg.IOF, cfg.Installment)
+			ev.Plan, ev.MonthlyRate = &plan, cfg.Installment.MonthlyRate
+		}
+		if ev.Plan == nil || len(ev.Plan.Installments) != ev.Installments || ev.Plan.TotalAmount != ev.Amount {
+			return fmt.Errorf("%w: purchase without its installment plan", ErrNotPriced)
+		}
+		if ev.Plan.TotalInterest > 0 && ev.MonthlyRate <= 0 {
+			return fmt.Errorf("%w: installment plan with interest but no contract rate", ErrNotPriced)
+		}
+		a.Plans = append(a.Plans, PlanState{PurchaseSeq: ev.Seq, Description: ev.Description, Plan: *ev.Plan, MonthlyRate: ev.MonthlyRate})
+		return nil
+	}
+
+	a.addLine(domain.InvoiceLinePurchase, ev.Description, ev.Amount)
+	if ev.International {
+		if cfg != nil {
+			ev.IOF = calc.CalculateInternationalIOF(ev.Amount, cfg.InternationalIOF)
+		}
+		a.addLine(domain.InvoiceLineIOF, "IOF internacional "+ev.Description, ev.IOF)
+	}
+	return nil
+}
+
+func (a *Account) payment(ev Event) error {
+	if ev.Amount <= 0 {
+		return calc.ErrInvalidPayment
+	}
+
+	left := ev.Amount
+	for i := range a.Invoices {
+		st := &a.Invoices[i]
+		remaining := st.Invoice.TotalAmount - st.Invoice.PaidAmount
+		if left == 0 || st.CarriedOver || remaining <= 0 || !payable(st.Invoice.Status) {
+			continue
+		}
+
+		pay := min(left, remaining)
+		inv, err := calc.ApplyInvoiceEvent(st.Invoice, calc.InvoiceEvent{Kind: calc.InvoiceEventPayment, Date: ev.Date, Amount: pay})
+		if err != nil {
+			return err
+		}
+		st.Invoice = inv
+		left -= pay
+	}
+
+	a.Open.PaidAmount += left
+	return nil
+}
+
+func (a *Account) closing(ev *Event, cfg *config.EngineConfig) error {
+	inv := a.Open
+	inv.ID = ev.InvoiceID
+	inv.DueDate = ev.DueDate
+	if cfg != nil {
+		ev.MinimumPayment = calc.WithMinimumPayment(inv, cfg.MinimumPayment).MinimumPayment
+	}
+	inv.MinimumPayment = ev.MinimumPayment
+
+	inv, err := calc.ApplyInvoiceEvent(inv, calc.InvoiceEvent{Kind: calc.InvoiceEventClose, Date: ev.Date})
+	if err != nil {
+		return err
+	}
+	if inv.PaidAmount >= inv.TotalAmount {
+		// Fully covered by credit: nothing left to pay.
+		credit := inv.PaidAmount - inv.TotalAmount
+		inv.PaidAmount = inv.TotalAmount
+		inv.MinimumPayment = 0
+		inv.Status = domain.InvoicePaid
+		a.Open = domain.Invoice{PaidAmount: credit}
+	} else {
+		a.Open = domain.Invoice{}
+	}
+
+	a.Invoices = append(a.Invoices, InvoiceState{Invoice: inv})
+	return nil
+}
+
+func (a *Account) chargePosted(ev *Event, cfg *config.EngineConfig) error {
+	st := a.invoice(ev.InvoiceID)
+	if st == nil {
+		return fmt.Errorf("%w %q", ErrUnknownInvoice, ev.InvoiceID)
+	}
+
+	balance, ok := calc.InvoiceRotativeBalance(st.Invoice)
+	if !ok || st.CarriedOver {
+		return fmt.Errorf("%w: %q is %s", ErrNoRotative, ev.InvoiceID, st.Invoice.Status)
+	}
+	balance.AccountID = a.ID
+
+	if cfg != nil {
+		result := calc.CalculateRotative(balance, ev.Date, cfg.IOF, cfg.Interest, cfg.LateFee, cfg.LateInterest, cfg.Rules)
+		ev.Rotative = &result
+	}
+	if ev.Rotative == nil || ev.Rotative.Principal != balance.Principal {
+		return fmt.Errorf("%w: charges of %q without the rotative result of its balance", ErrNotPriced, ev.InvoiceID)
+	}
+	result := *ev.Rotative
+	a.Rotative = append(a.Rotative, result)
+	st.CarriedOver = true
+
+	a.addLine(domain.InvoiceLinePurchase, "Saldo anterior "+ev.InvoiceID, result.Principal)
+	for _, line := range calc.RotativeInvoiceLines(result) {
+		a.addLine(line.Kind, line.Description, line.Amount)
+	}
+	return nil
+}
+
+func (a *Account) installmentPosted(ev Event) error {
+	var ps *PlanState
+	for i := range a.Plans {
+		if a.Plans[i].PurchaseSeq == ev.PlanSeq {
+			ps = &a.Plans[i]
+		}
+	}
+	if ps == nil {
+		return fmt.Errorf("%w: purchase %d", ErrUnknownPlan, ev.PlanSeq)
+	}
+	if ev.InstallmentNumber != ps.Posted+1 || ev.InstallmentNumber > len(ps.Plan.Installments) {
+		return fmt.Errorf("%w: parcel %d after %d of %d", ErrInstallmentPosted, ev.InstallmentNumber, ps.Posted, len(ps.Plan.Installments))
+	}
+
+	inst := ps.Plan.Installments[ps.Posted]
+	ps.Posted++
+	for _, line := range calc.InstallmentInvoiceLines(ps.Description, ps.Plan, inst.DueDate) {
+		a.addLine(line.Kind, line.Description, line.Amount)
+	}
+	return nil
+}
+
+func (a *Account) addLine(kind domain.InvoiceLineKind, description string, amount domain.Money) {
+	if amount == 0 {
+		return
+	}
+	a.Open.Lines = append(a.Open.Lines, domain.InvoiceLine{Kind: kind, Description: description, Amount: amount})
+	a.Open.TotalAmount += amount
+}
+
+func (a *Account) invoice(id string) *InvoiceState {
+	for i := range a.Invoices {
+		if a.Invoices[i].Invoice.ID == id {
+			return &a.Invoices[i]
+		}
+	}
+	return nil
+}
+
+func (a *Account) updateBalance() {
+	a.Balance = a.Open.TotalAmount - a.Open.PaidAmount
+	for _, st := range a.Invoices {
+		if !st.CarriedOver {
+			a.Balance += st.Invoice.TotalAmount - st.Invoice.PaidAmount
+		}
+	}
+}
+
+func payable(status domain.InvoiceStatus) bool {
+	switch status {
+	case domain.InvoiceClosed, domain.InvoicePartiallyPaid, domain.InvoiceOverdue, domain.InvoiceInRotative:
+		return true
+	}
+	return false
+}
+
+// PayoffInput returns the state calc.CalculatePayoff quotes on at: the open
+// cycle and invoices not yet revolving as billed, revolving invoices (due
+// dates up to at included) as rotative balances and every plan with parcels
+// left, discounted at the contract rate it was priced at.
+func (a Account) PayoffInput(at time.Time) calc.PayoffInput {
+	in := calc.PayoffInput{Billed: a.Open.TotalAmount - a.Open.PaidAmount}
+	for _, st := range a.Invoices {
+		if st.CarriedOver {
+			continue
+		}
+		inv, err := calc.ApplyInvoiceEvent(st.Invoice, calc.InvoiceEvent{Kind: calc.InvoiceEventDayPassed, Date: at})
+		if err == nil {
+			st.Invoice = inv
+		}
+		if balance, ok := calc.InvoiceRotativeBalance(st.Invoice); ok {
+			balance.AccountID = a.ID
+			in.Rotative = append(in.Rotative, balance)
+			continue
+		}
+		if payable(st.Invoice.Status) {
+			in.Billed += st.Invoice.TotalAmount - st.Invoice.PaidAmount
+		}
+	}
+	for _, ps := range a.Plans {
+		if ps.Posted < len(ps.Plan.Installments) {
+			in.Plans = append(in.Plans, calc.PayoffPlan{Plan: ps.Plan, MonthlyRate: ps.MonthlyRate, Posted: ps.Posted})
+		}
+	}
+	return in
+}
@@ -0,0 +1,39 @@
+package ledger
+
+import "sync"
+
+// Store persists events append-only. Implementations must assign Seq
+// (1, 2, ... per account) and return events of an account in Seq order.
+type Store interface {
+	Append(ev Event) (Event, error)
+	Events(accountID string) ([]Event, error)
+}
+
+// MemoryStore is an in-memory Store, safe for concurrent use.
+type MemoryStore struct {
+	mu     sync.Mutex
+	events map[string][]Event
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{events: make(map[string][]Event)}
+}
+
+func (s *MemoryStore) Append(ev Event) (Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ev.Seq = int64(len(s.events[ev.AccountID])) + 1
+	s.events[ev.AccountID] = append(s.events[ev.AccountID], ev)
+	return ev, nil
+}
+
+func (s *MemoryStore) Events(accountID string) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.events[accountID]
+	out := make([]Event, len(events))
+	copy(out, events)
+	return out, nil
+}
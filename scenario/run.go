@@ -0,0 +1,283 @@
+package scenario
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+	"github.com/thiagozs/go-calc-charges-engine/ledger"
+	"github.com/thiagozs/go-calc-charges-engine/service"
+)
+
+// Result is the outcome of a scenario run.
+type Result struct {
+	Name  string
+	Steps []StepResult
+}
+
+// StepResult holds every output of a step and the expectations it missed.
+type StepResult struct {
+	Index      int
+	ID         string
+	Action     Action
+	Outputs    map[string]int64
+	Mismatches []Mismatch
+}
+
+// Mismatch is an expected output that differs from the actual one. Unknown
+// is set when the step has no output with that key.
+type Mismatch struct {
+	Key       string
+	Want, Got int64
+	Unknown   bool
+}
+
+// Failed reports whether any expectation was missed.
+func (r Result) Failed() bool {
+	for _, s := range r.Steps {
+		if len(s.Mismatches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures describes each missed expectation, one per line.
+func (r Result) Failures() []string {
+	var out []string
+	for _, s := range r.Steps {
+		for _, m := range s.Mismatches {
+			step := fmt.Sprintf("step %d (%s)", s.Index+1, s.Action)
+			if s.ID != "" {
+				step = fmt.Sprintf("step %d %q (%s)", s.Index+1, s.ID, s.Action)
+			}
+			if m.Unknown {
+				out = append(out, fmt.Sprintf("%s: unknown output %q (have %s)", step, m.Key, strings.Join(slices.Sorted(maps.Keys(s.Outputs)), ", ")))
+				continue
+			}
+			out = append(out, fmt.Sprintf("%s: %s = %d, want %d", step, m.Key, m.Got, m.Want))
+		}
+	}
+	return out
+}
+
+// Run executes the steps in order against a fresh in-memory ledger and the
+// services built from the scenario config, and compares every expectation.
+//
+// Outputs of each action:
+//
+//   - ledger events: balance, open_total, open_paid; closing adds
+//     invoice_total and invoice_minimum_payment, charge_posted adds the
+//     rotative outputs and an installment purchase adds the plan outputs;
+//   - rotative: principal, interest, iof, late_fee, late_interest, charges,
+//     total, days, charged_days, charge_capped (0 or 1), rounding_residue;
+//   - apply_payment: paid_iof, paid_interest, paid_late_interest,
+//     paid_late_fee, paid_principal, remaining;
+//   - installment_plan: total_amount, total_iof, total_interest,
+//     total_with_iof, grace_interest, rounding_residue and, per parcel N,
+//     installment.N.principal, .interest, .iof and .amount;
+//   - payoff: billed, rotative_total, installment_total, discount, total.
+//
+// Missed expectations are reported in the Result; an error is returned for
+// an invalid scenario or a step that fails other than as ExpectError says.
+func Run(s Scenario) (Result, error) {
+	cfg, err := s.EngineConfig()
+	if err != nil {
+		return Result{}, err
+	}
+	r := runner{
+		cfg:       cfg,
+		accountID: s.AccountID,
+		ledger:    ledger.New(ledger.NewMemoryStore(), cfg),
+		rotative:  service.NewRotativeService(cfg),
+		inst:      service.NewInstallmentService(cfg),
+		payoff:    service.NewPayoffService(cfg),
+		purchases: make(map[string]int64),
+	}
+	if r.accountID == "" {
+		r.accountID = "scenario"
+	}
+
+	res := Result{Name: s.Name}
+	for i, step := range s.Steps {
+		outputs, err := r.step(step)
+		switch {
+		case err != nil && step.ExpectError == "":
+			return res, fmt.Errorf("scenario %q: step %d (%s): %w", s.Name, i+1, step.Action, err)
+		case err != nil && !strings.Contains(err.Error(), step.ExpectError):
+			return res, fmt.Errorf("scenario %q: step %d (%s): expected error %q, got %w", s.Name, i+1, step.Action, step.ExpectError, err)
+		case err == nil && step.ExpectError != "":
+			return res, fmt.Errorf("scenario %q: step %d (%s): expected error %q", s.Name, i+1, step.Action, step.ExpectError)
+		}
+
+		sr := StepResult{Index: i, ID: step.ID, Action: step.Action, Outputs: outputs}
+		for _, key := range slices.Sorted(maps.Keys(step.Expect)) {
+			want := step.Expect[key]
+			got, ok := outputs[key]
+			switch {
+			case !ok:
+				sr.Mismatches = append(sr.Mismatches, Mismatch{Key: key, Want: want, Unknown: true})
+			case got != want:
+				sr.Mismatches = append(sr.Mismatches, Mismatch{Key: key, Want: want, Got: got})
+			}
+		}
+		res.Steps = append(res.Steps, sr)
+	}
+	return res, nil
+}
+
+type runner struct {
+	cfg       config.EngineConfig
+	accountID string
+	ledger    *ledger.Ledger
+	rotative  *service.RotativeService
+	inst      *service.InstallmentService
+	payoff    *service.PayoffService
+
+	purchases    map[string]int64 // purchase step ID -> ledger Seq
+	lastRotative *calc.RotativeResult
+}
+
+func (r *runner) step(s Step) (map[string]int64, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	switch s.Action {
+	case ActionPurchase, ActionPayment, ActionClosing, ActionChargePosted, ActionInstallmentPosted:
+		return r.event(s)
+	case ActionRotative:
+		balance := domain.RotativeBalance{AccountID: r.accountID, Principal: s.Amount, StartDate: s.StartDate.Time}
+		result := r.rotative.Calculate(balance, s.Date.Time)
+		r.lastRotative = &result
+		return rotativeOutputs(result), nil
+	case ActionApplyPayment:
+		if r.lastRotative == nil {
+			return nil, fmt.Errorf("no rotative result to pay")
+		}
+		return amortizationOutputs(r.rotative.ApplyPayment(*r.lastRotative, s.Amount)), nil
+	case ActionInstallmentPlan:
+		plan := r.inst.CalculateForAccount(r.accountID, s.Amount, s.Installments, s.Date.Time, s.FirstDueDate.Time)
+		return planOutputs(plan), nil
+	case ActionPayoff:
+		acc, err := r.ledger.Account(r.accountID)
+		if err != nil {
+			return nil, err
+		}
+		q := r.payoff.Quote(r.accountID, acc.PayoffInput(s.Date.Time), s.Date.Time)
+		return map[string]int64{
+			"billed":            int64(q.Billed),
+			"rotative_total":    int64(q.RotativeTotal),
+			"installment_total": int64(q.InstallmentTotal),
+			"discount":          int64(q.Discount),
+			"total":             int64(q.Total),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown action %q", s.Action)
+	}
+}
+
+func (r *runner) event(s Step) (map[string]int64, error) {
+	ev := ledger.Event{
+		AccountID:         r.accountID,
+		Kind:              ledger.EventKind(s.Action),
+		Date:              s.Date.Time,
+		Amount:            s.Amount,
+		Description:       s.Description,
+		International:     s.International,
+		Installments:      s.Installments,
+		FirstDueDate:      s.FirstDueDate.Time,
+		InvoiceID:         s.InvoiceID,
+		DueDate:           s.DueDate.Time,
+		InstallmentNumber: s.Installment,
+	}
+	if s.Action == ActionInstallmentPosted {
+		seq, ok := r.purchases[s.Purchase]
+		if !ok {
+			return nil, fmt.Errorf("unknown purchase step %q", s.Purchase)
+		}
+		ev.PlanSeq = seq
+	}
+
+	stored, acc, err := r.ledger.Append(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]int64{
+		"balance":    int64(acc.Balance),
+		"open_total": int64(acc.Open.TotalAmount),
+		"open_paid":  int64(acc.Open.PaidAmount),
+	}
+	switch s.Action {
+	case ActionPurchase:
+		if s.ID != "" {
+			r.purchases[s.ID] = stored.Seq
+		}
+		if n := len(acc.Plans); n > 0 && acc.Plans[n-1].PurchaseSeq == stored.Seq {
+			maps.Copy(outputs, planOutputs(acc.Plans[n-1].Plan))
+		}
+	case ActionClosing:
+		inv := acc.Invoices[len(acc.Invoices)-1].Invoice
+		outputs["invoice_total"] = int64(inv.TotalAmount)
+		outputs["invoice_minimum_payment"] = int64(inv.MinimumPayment)
+	case ActionChargePosted:
+		result := acc.Rotative[len(acc.Rotative)-1]
+		r.lastRotative = &result
+		maps.Copy(outputs, rotativeOutputs(result))
+	}
+	return outputs, nil
+}
+
+func rotativeOutputs(r calc.RotativeResult) map[string]int64 {
+	capped := int64(0)
+	if r.ChargeCapped {
+		capped = 1
+	}
+	return map[string]int64{
+		"principal":        int64(r.Principal),
+		"interest":         int64(r.Interest),
+		"iof":              int64(r.IOF),
+		"late_fee":         int64(r.LateFee),
+		"late_interest":    int64(r.LateInterest),
+		"charges":          int64(r.Charges),
+		"total":            int64(r.Total),
+		"days":             int64(r.Days),
+		"charged_days":     int64(r.ChargedDays),
+		"charge_capped":    capped,
+		"rounding_residue": r.RoundingResidue,
+	}
+}
+
+func amortizationOutputs(a calc.AmortizationResult) map[string]int64 {
+	return map[string]int64{
+		"paid_iof":           int64(a.PaidIOF),
+		"paid_interest":      int64(a.PaidInterest),
+		"paid_late_interest": int64(a.PaidLateInterest),
+		"paid_late_fee":      int64(a.PaidLateFee),
+		"paid_principal":     int64(a.PaidPrincipal),
+		"remaining":          int64(a.Remaining),
+	}
+}
+
+func planOutputs(p domain.InstallmentPlan) map[string]int64 {
+	out := map[string]int64{
+		"total_amount":     int64(p.TotalAmount),
+		"total_iof":        int64(p.TotalIOF),
+		"total_interest":   int64(p.TotalInterest),
+		"total_with_iof":   int64(p.TotalWithIOF),
+		"grace_interest":   int64(p.GraceInterest),
+		"rounding_residue": p.RoundingResidue,
+	}
+	for _, inst := range p.Installments {
+		prefix := fmt.Sprintf("installment.%d.", inst.Number)
+		out[prefix+"principal"] = int64(inst.Principal)
+		out[prefix+"interest"] = int64(inst.Interest)
+		out[prefix+"iof"] = int64(inst.IOF)
+		out[prefix+"amount"] = int64(inst.Amount)
+	}
+	return out
+}
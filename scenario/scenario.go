@@ -0,0 +1,167 @@
+// Package scenario runs regression cases written in JSON: a config, a list of
+// dated steps (ledger events and service calls) and the amounts each step is
+// expected to produce. It lets disputed invoices be reproduced without Go:
+// copy the facts into a file under scenario/testdata and run go test.
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// Action is what a step does.
+type Action string
+
+const (
+	// Ledger events, appended to the scenario account (see package ledger).
+	ActionPurchase          Action = "purchase"
+	ActionPayment           Action = "payment"
+	ActionClosing           Action = "closing"
+	ActionChargePosted      Action = "charge_posted"
+	ActionInstallmentPosted Action = "installment_posted"
+
+	// Service calls.
+	ActionRotative        Action = "rotative"         // RotativeService.Calculate
+	ActionApplyPayment    Action = "apply_payment"    // RotativeService.ApplyPayment on the last rotative result
+	ActionInstallmentPlan Action = "installment_plan" // InstallmentService.CalculateForAccount
+	ActionPayoff          Action = "payoff"           // PayoffService.Quote of the scenario account
+)
+
+// Scenario is a regression case.
+type Scenario struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	AccountID   string `json:"account_id,omitempty"`
+	// Config is merged over config.Defaults, with the field names of
+	// config.EngineConfig, e.g. {"Interest": {"MonthlyRate": 150000}}.
+	Config json.RawMessage `json:"config,omitempty"`
+	Steps  []Step          `json:"steps"`
+}
+
+// Step is one dated action. Only the fields of its Action are read. Amounts
+// are in centavos and dates are YYYY-MM-DD.
+type Step struct {
+	ID     string `json:"id,omitempty"`
+	Action Action `json:"action"`
+	Date   Date   `json:"date"`
+
+	// purchase, payment, rotative (principal), apply_payment (payment) and
+	// installment_plan.
+	Amount        domain.Money `json:"amount,omitempty"`
+	Description   string       `json:"description,omitempty"`
+	International bool         `json:"international,omitempty"`
+	Installments  int          `json:"installments,omitempty"`
+	FirstDueDate  Date         `json:"first_due_date,omitzero"`
+
+	// closing and charge_posted.
+	InvoiceID string `json:"invoice_id,omitempty"`
+	DueDate   Date   `json:"due_date,omitzero"`
+
+	// installment_posted: the ID of the purchase step and the parcel number.
+	Purchase    string `json:"purchase,omitempty"`
+	Installment int    `json:"installment,omitempty"`
+
+	// rotative: the day the balance started revolving.
+	StartDate Date `json:"start_date,omitzero"`
+
+	// Expect maps output keys (listed in Run) to their expected values. Keys
+	// left out are not checked.
+	Expect map[string]int64 `json:"expect,omitempty"`
+	// ExpectError, if set, must be contained in the error of the step.
+	ExpectError string `json:"expect_error,omitempty"`
+}
+
+// validate checks that the fields its action requires are set, so a
+// malformed step fails as a step error instead of reaching the engine with
+// zero values. The ledger validates the rest of its events itself.
+func (s Step) validate() error {
+	var missing string
+	switch {
+	case s.Date.IsZero():
+		missing = "date"
+	case s.Action == ActionInstallmentPlan && s.Amount <= 0:
+		missing = "amount"
+	case s.Action == ActionInstallmentPlan && s.Installments < 1:
+		missing = "installments"
+	case s.Action == ActionInstallmentPlan && s.FirstDueDate.IsZero():
+		missing = "first_due_date"
+	case s.Action == ActionRotative && s.StartDate.IsZero():
+		missing = "start_date"
+	case (s.Action == ActionClosing || s.Action == ActionChargePosted) && s.InvoiceID == "":
+		missing = "invoice_id"
+	case s.Action == ActionClosing && s.DueDate.IsZero():
+		missing = "due_date"
+	case s.Action == ActionInstallmentPosted && s.Purchase == "":
+		missing = "purchase"
+	default:
+		return nil
+	}
+	return fmt.Errorf("scenario: %s step without %s", s.Action, missing)
+}
+
+// Date is a calendar day in UTC, encoded as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(time.DateOnly))
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return fmt.Errorf("scenario: invalid date %q, want YYYY-MM-DD", s)
+	}
+	d.Time = t
+	return nil
+}
+
+// Load decodes a scenario. Unknown fields are rejected so typos surface.
+func Load(r io.Reader) (Scenario, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var s Scenario
+	if err := dec.Decode(&s); err != nil {
+		return Scenario{}, fmt.Errorf("scenario: decode: %w", err)
+	}
+	return s, nil
+}
+
+// LoadFile loads the scenario at path.
+func LoadFile(path string) (Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// EngineConfig returns config.Defaults with Config merged over it.
+func (s Scenario) EngineConfig() (config.EngineConfig, error) {
+	cfg, err := config.Defaults()
+	if err != nil {
+		return config.EngineConfig{}, err
+	}
+	if len(s.Config) == 0 {
+		return cfg, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(s.Config))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return config.EngineConfig{}, fmt.Errorf("scenario: config: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,132 @@
+package scenario
+
+import (
+	"encoding/json"
+	"flag"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// update rewrites the expectations of the scenarios under testdata with the
+// current outputs: every output for steps without expectations, only the keys
+// already listed otherwise. Review the diff before committing.
+var update = flag.Bool("update", false, "rewrite scenario expectations with the current outputs")
+
+func TestScenarios(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no scenarios under testdata")
+	}
+
+	for _, path := range paths {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			s, err := LoadFile(path)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			res, err := Run(s)
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+
+			if *update {
+				writeExpectations(t, path, s, res)
+				return
+			}
+			for _, f := range res.Failures() {
+				t.Error(f)
+			}
+		})
+	}
+}
+
+func writeExpectations(t *testing.T, path string, s Scenario, res Result) {
+	t.Helper()
+	for i, sr := range res.Steps {
+		step := &s.Steps[i]
+		if len(step.Expect) == 0 {
+			step.Expect = maps.Clone(sr.Outputs)
+			continue
+		}
+		for key := range step.Expect {
+			if got, ok := sr.Outputs[key]; ok {
+				step.Expect[key] = got
+			}
+		}
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func TestRun_ReportsMismatchesAndUnknownKeys(t *testing.T) {
+	s, err := Load(strings.NewReader(`{
+		"name": "mismatch",
+		"steps": [
+			{"action": "rotative", "date": "2024-02-09", "start_date": "2024-01-10", "amount": 123457,
+			 "expect": {"charges": 1, "intrest": 0}}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	res, err := Run(s)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	failures := res.Failures()
+	if !res.Failed() || len(failures) != 2 {
+		t.Fatalf("expected 2 failures, got %q", failures)
+	}
+	if !strings.Contains(failures[0], "charges = 19292, want 1") || !strings.Contains(failures[1], `unknown output "intrest"`) {
+		t.Fatalf("unexpected failures %q", failures)
+	}
+}
+
+func TestRun_StepErrors(t *testing.T) {
+	cases := []struct {
+		name, doc, want string
+	}{
+		{"unknown action", `{"name": "x", "steps": [{"action": "refund", "date": "2024-01-01"}]}`, `unknown action "refund"`},
+		{"unexpected ledger error", `{"name": "x", "steps": [{"action": "charge_posted", "date": "2024-01-01", "invoice_id": "inv-9"}]}`, "unknown invoice"},
+		{"missing expected error", `{"name": "x", "steps": [{"action": "purchase", "date": "2024-01-01", "amount": 100, "installments": 1, "expect_error": "boom"}]}`, `expected error "boom"`},
+		{"installment plan without installments", `{"name": "x", "steps": [{"action": "installment_plan", "date": "2024-01-05", "amount": 100000, "first_due_date": "2024-02-10"}]}`, "installment_plan step without installments"},
+		{"rotative without start date", `{"name": "x", "steps": [{"action": "rotative", "date": "2024-01-05", "amount": 100000}]}`, "rotative step without start_date"},
+		{"unknown config field", `{"name": "x", "config": {"Interest": {"Monthly": 1}}, "steps": []}`, "unknown field"},
+	}
+	for _, tc := range cases {
+		s, err := Load(strings.NewReader(tc.doc))
+		if err != nil {
+			t.Fatalf("%s: load: %v", tc.name, err)
+		}
+		if _, err := Run(s); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	// An expected error lets the scenario go on.
+	s, _ := Load(strings.NewReader(`{"name": "x", "steps": [{"action": "charge_posted", "date": "2024-01-01", "invoice_id": "inv-9", "expect_error": "unknown invoice"}]}`))
+	if _, err := Run(s); err != nil {
+		t.Fatalf("expected the error to be accepted, got %v", err)
+	}
+}
+
+func TestLoad_RejectsUnknownFieldsAndBadDates(t *testing.T) {
+	if _, err := Load(strings.NewReader(`{"name": "x", "steps": [{"action": "payment", "dat": "2024-01-01"}]}`)); err == nil {
+		t.Fatal("expected unknown field error")
+	}
+	if _, err := Load(strings.NewReader(`{"name": "x", "steps": [{"action": "payment", "date": "01/01/2024"}]}`)); err == nil || !strings.Contains(err.Error(), "YYYY-MM-DD") {
+		t.Fatalf("expected date error, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// ErrInvalidBalance is reported per item for balances that cannot be calculated.
+var ErrInvalidBalance = errors.New("service: invalid rotative balance")
+
+// BatchOptions configures CalculateBatch. The zero value is usable.
+type BatchOptions struct {
+	// Workers bounds the concurrent calculations; <= 0 means GOMAXPROCS.
+	Workers int
+	// ChunkSize is how many items a worker takes at a time (default 256).
+	// Larger chunks lower the scheduling overhead; smaller ones react faster
+	// to cancellation.
+	ChunkSize int
+	// OnResult, if set, receives every item, failed ones included. Calls are
+	// serialized but not in input order (use BatchItem.Index).
+	OnResult func(BatchItem)
+	// OnProgress, if set, is called every ProgressEvery items (default 1000)
+	// and once at the end. Calls are serialized.
+	OnProgress    func(BatchProgress)
+	ProgressEvery int
+}
+
+// BatchItem is the outcome of one balance of a batch.
+type BatchItem struct {
+	Index   int // position in the input sequence
+	Balance domain.RotativeBalance
+	Result  calc.RotativeResult
+	Err     error
+}
+
+// BatchProgress reports how many items a batch has finished.
+type BatchProgress struct {
+	Processed int
+	Failed    int
+}
+
+// BatchTotals aggregates the successful results of a batch.
+type BatchTotals struct {
+	Count        int
+	Failed       int
+	Principal    domain.Money
+	Interest     domain.Money
+	IOF          domain.Money
+	LateFee      domain.Money
+	LateInterest domain.Money
+	Charges      domain.Money
+	Total        domain.Money
+	Capped       int // results with ChargeCapped
+}
+
+func (t *BatchTotals) add(r *calc.RotativeResult) {
+	t.Count++
+	t.Principal += r.Principal
+	t.Interest += r.Interest
+	t.IOF += r.IOF
+	t.LateFee += r.LateFee
+	t.LateInterest += r.LateInterest
+	t.Charges += r.Charges
+	t.Total += r.Total
+	if r.ChargeCapped {
+		t.Capped++
+	}
+}
+
+// CalculateBatch runs Calculate for every balance at the given date with a
+// bounded worker pool, for end-of-day accruals over many accounts.
+//
+// Invalid balances (negative principal, zero start date) and panics are
+// reported per item and do not stop the batch. If ctx is cancelled, no new
+// items are started, in-flight items finish and the totals so far are
+// returned with ctx.Err().
+func (s *RotativeService) CalculateBatch(
+	ctx context.Context,
+	balances iter.Seq[domain.RotativeBalance],
+	at time.Time,
+	opts BatchOptions,
+) (BatchTotals, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = 256
+	}
+	every := opts.ProgressEvery
+	if every <= 0 {
+		every = 1000
+	}
+
+	jobs := make(chan []BatchItem, workers)
+	results := make(chan []BatchItem, workers)
+	// Collected chunks are handed back to the producer for reuse.
+	free := make(chan []BatchItem, 2*workers+1)
+	newChunk := func() []BatchItem {
+		select {
+		case chunk := <-free:
+			return chunk[:0]
+		default:
+			return make([]BatchItem, 0, chunkSize)
+		}
+	}
+
+	go func() {
+		defer close(jobs)
+		send := func(chunk []BatchItem) bool {
+			select {
+			case jobs <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		chunk := newChunk()
+		i := 0
+		for balance := range balances {
+			chunk = append(chunk, BatchItem{Index: i, Balance: balance})
+			i++
+			if len(chunk) == chunkSize {
+				if !send(chunk) {
+					return
+				}
+				chunk = newChunk()
+			}
+		}
+		if len(chunk) > 0 {
+			send(chunk)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				for i := range chunk {
+					chunk[i].Result, chunk[i].Err = s.calculateItem(chunk[i].Balance, at)
+				}
+				results <- chunk
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var totals BatchTotals
+	for chunk := range results {
+		for i := range chunk {
+			item := &chunk[i]
+			if item.Err != nil {
+				totals.Failed++
+			} else {
+				totals.add(&item.Result)
+			}
+			if opts.OnResult != nil {
+				opts.OnResult(*item)
+			}
+			if processed := totals.Count + totals.Failed; opts.OnProgress != nil && processed%every == 0 {
+				opts.OnProgress(BatchProgress{Processed: processed, Failed: totals.Failed})
+			}
+		}
+		select {
+		case free <- chunk:
+		default:
+		}
+	}
+	if opts.OnProgress != nil {
+		opts.OnProgress(BatchProgress{Processed: totals.Count + totals.Failed, Failed: totals.Failed})
+	}
+
+	return totals, ctx.Err()
+}
+
+func (s *RotativeService) calculateItem(balance domain.RotativeBalance, at time.Time) (result calc.RotativeResult, err error) {
+	switch {
+	case balance.Principal < 0:
+		return calc.RotativeResult{}, fmt.Errorf("%w: account %q: negative principal", ErrInvalidBalance, balance.AccountID)
+	case balance.Principal > calc.MaxAmount:
+		return calc.RotativeResult{}, fmt.Errorf("%w: account %q: principal above calc.MaxAmount", ErrInvalidBalance, balance.AccountID)
+	case balance.StartDate.IsZero():
+		return calc.RotativeResult{}, fmt.Errorf("%w: account %q: missing start date", ErrInvalidBalance, balance.AccountID)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = calc.RotativeResult{}, fmt.Errorf("service: account %q: %v", balance.AccountID, r)
+		}
+	}()
+	return s.Calculate(balance, at), nil
+}
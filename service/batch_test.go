@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func balances(n int) iter.Seq[domain.RotativeBalance] {
+	return func(yield func(domain.RotativeBalance) bool) {
+		for i := range n {
+			b := domain.RotativeBalance{
+				AccountID: fmt.Sprintf("acc-%d", i),
+				Principal: domain.Money(10_000 + i%50_000),
+				StartDate: utcDate(2024, 1, 1+i%28),
+			}
+			if !yield(b) {
+				return
+			}
+		}
+	}
+}
+
+func TestCalculateBatch_TotalsMatchSequential(t *testing.T) {
+	svc := NewRotativeService(testEngineConfig())
+	at := utcDate(2024, 2, 10)
+
+	var want BatchTotals
+	for b := range balances(5_000) {
+		r := svc.Calculate(b, at)
+		want.add(&r)
+	}
+
+	var progress []BatchProgress
+	got, err := svc.CalculateBatch(context.Background(), balances(5_000), at, BatchOptions{
+		Workers:       4,
+		ProgressEvery: 1_000,
+		OnProgress:    func(p BatchProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if len(progress) != 6 || progress[len(progress)-1].Processed != 5_000 {
+		t.Fatalf("expected 5 progress reports plus the final one, got %+v", progress)
+	}
+}
+
+func TestCalculateBatch_PerItemErrors(t *testing.T) {
+	svc := NewRotativeService(testEngineConfig())
+	input := func(yield func(domain.RotativeBalance) bool) {
+		_ = yield(domain.RotativeBalance{AccountID: "ok", Principal: 10_000, StartDate: utcDate(2024, 1, 10)}) &&
+			yield(domain.RotativeBalance{AccountID: "negative", Principal: -1, StartDate: utcDate(2024, 1, 10)}) &&
+			yield(domain.RotativeBalance{AccountID: "no-date", Principal: 10_000}) &&
+			yield(domain.RotativeBalance{AccountID: "too-large", Principal: calc.MaxAmount + 1, StartDate: utcDate(2024, 1, 10)})
+	}
+
+	failed := map[int]error{}
+	totals, err := svc.CalculateBatch(context.Background(), input, utcDate(2024, 2, 10), BatchOptions{
+		OnResult: func(item BatchItem) {
+			if item.Err != nil {
+				failed[item.Index] = item.Err
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+	if totals.Count != 1 || totals.Failed != 3 {
+		t.Fatalf("expected 1 ok and 3 failed, got %+v", totals)
+	}
+	if !errors.Is(failed[1], ErrInvalidBalance) || !errors.Is(failed[2], ErrInvalidBalance) || !errors.Is(failed[3], ErrInvalidBalance) {
+		t.Fatalf("expected ErrInvalidBalance for items 1 to 3, got %v", failed)
+	}
+}
+
+func TestCalculateBatch_Cancellation(t *testing.T) {
+	svc := NewRotativeService(testEngineConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	totals, err := svc.CalculateBatch(ctx, balances(1_000_000), utcDate(2024, 2, 10), BatchOptions{
+		Workers: 2,
+		OnResult: func(item BatchItem) {
+			if item.Index == 100 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if totals.Count == 0 || totals.Count >= 1_000_000 {
+		t.Fatalf("expected a partial batch, got %d items", totals.Count)
+	}
+}
+
+func BenchmarkCalculateBatch(b *testing.B) {
+	svc := NewRotativeService(testEngineConfig())
+	at := utcDate(2024, 2, 10)
+
+	for _, workers := range []int{1, 4, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := svc.CalculateBatch(context.Background(), balances(10_000), at, BatchOptions{Workers: workers}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(10_000*b.N)/b.Elapsed().Seconds(), "accounts/s")
+		})
+	}
+}
+
+func BenchmarkCalculateSequential(b *testing.B) {
+	svc := NewRotativeService(testEngineConfig())
+	at := utcDate(2024, 2, 10)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		for balance := range balances(10_000) {
+			svc.Calculate(balance, at)
+		}
+	}
+	b.ReportMetric(float64(10_000*b.N)/b.Elapsed().Seconds(), "accounts/s")
+}
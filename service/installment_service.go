@@ -3,6 +3,7 @@ package service
 import (
 	"time"
 
+	"github.com/thiagozs/go-calc-charges-engine/audit"
 	"github.com/thiagozs/go-calc-charges-engine/calc"
 	"github.com/thiagozs/go-calc-charges-engine/config"
 	"github.com/thiagozs/go-calc-charges-engine/domain"
@@ -11,6 +12,8 @@ import (
 type InstallmentService struct {
 	IOFConfig         config.IOFConfig
 	InstallmentConfig config.InstallmentConfig
+	// Overrides holds per-account negotiated rates. Nil means product rates only.
+	Overrides config.OverrideProvider
 }
 
 func (s *InstallmentService) Calculate(
@@ -19,11 +22,71 @@ func (s *InstallmentService) Calculate(
 	purchaseDate time.Time,
 	firstDueDate time.Time,
 ) domain.InstallmentPlan {
-	return calc.CalculateInstallmentPlan(
+	return s.CalculateForAccount("", amount, numInstallments, purchaseDate, firstDueDate)
+}
+
+// CalculateForAccount is like Calculate, but merges the override active for
+// the account at purchaseDate over the product config.
+func (s *InstallmentService) CalculateForAccount(
+	accountID string,
+	amount domain.Money,
+	numInstallments int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+) domain.InstallmentPlan {
+	plan, _ := s.calculate(accountID, amount, numInstallments, purchaseDate, firstDueDate)
+	return plan
+}
+
+// CalculateAudited is like CalculateForAccount, but also returns the audit
+// envelope with the input, the resolved config snapshot and the engine version.
+func (s *InstallmentService) CalculateAudited(
+	accountID string,
+	amount domain.Money,
+	numInstallments int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+) (domain.InstallmentPlan, audit.Envelope, error) {
+	plan, cfg := s.calculate(accountID, amount, numInstallments, purchaseDate, firstDueDate)
+	env, err := audit.SealInstallment(
+		audit.InstallmentInput{
+			TotalAmount:     amount,
+			NumInstallments: numInstallments,
+			PurchaseDate:    purchaseDate,
+			FirstDueDate:    firstDueDate,
+			Override:        plan.Override,
+		},
+		cfg,
+		plan,
+	)
+	if err != nil {
+		return domain.InstallmentPlan{}, audit.Envelope{}, err
+	}
+	return plan, env, nil
+}
+
+func (s *InstallmentService) calculate(
+	accountID string,
+	amount domain.Money,
+	numInstallments int,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+) (domain.InstallmentPlan, audit.InstallmentConfig) {
+	cfg := audit.InstallmentConfig{IOF: s.IOFConfig, Installment: s.InstallmentConfig}
+
+	var ref *domain.OverrideRef
+	if o, ok := resolveOverride(s.Overrides, accountID, purchaseDate); ok {
+		cfg.Installment = o.ApplyInstallment(cfg.Installment)
+		ref = o.Ref()
+	}
+
+	plan := calc.CalculateInstallmentPlan(
 		amount, numInstallments,
 		purchaseDate, firstDueDate,
-		s.IOFConfig, s.InstallmentConfig,
+		cfg.IOF, cfg.Installment,
 	)
+	plan.Override = ref
+	return plan, cfg
 }
 
 func NewInstallmentService(cfg config.EngineConfig) *InstallmentService {
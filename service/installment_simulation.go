@@ -0,0 +1,64 @@
+package service
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// InstallmentOffer is a single checkout option ("10x sem juros", "12x de R$ X").
+type InstallmentOffer struct {
+	Installments int
+	InterestFree bool
+	// InstallmentAmount is the parcel shown at checkout: the largest
+	// principal plus interest of the plan, plus the IOF when the IOF is
+	// financed (see calc.LargestParcel).
+	InstallmentAmount domain.Money
+	TotalInterest     domain.Money
+	TotalIOF          domain.Money
+	TotalWithIOF      domain.Money
+	CET               calc.CET
+	Plan              domain.InstallmentPlan
+}
+
+// Simulate returns every offer allowed by the policy, from 1x up to
+// policy.MaxInstallments, skipping those whose parcel is below the minimum.
+func (s *InstallmentService) Simulate(
+	amount domain.Money,
+	purchaseDate time.Time,
+	firstDueDate time.Time,
+	policy config.InstallmentPolicyConfig,
+) []InstallmentOffer {
+	offers := make([]InstallmentOffer, 0, policy.MaxInstallments)
+	for n := 1; n <= policy.MaxInstallments; n++ {
+		instCfg := s.InstallmentConfig
+		interestFree := n <= policy.InterestFreeUpTo
+		if interestFree {
+			instCfg.MonthlyRate = 0
+		} else {
+			instCfg.MonthlyRate = policy.MonthlyRate
+		}
+
+		plan := calc.CalculateInstallmentPlan(amount, n, purchaseDate, firstDueDate, s.IOFConfig, instCfg)
+		parcel := calc.LargestParcel(plan, false)
+		if n > 1 && parcel < policy.MinInstallmentAmount {
+			// Not a break: the first interest-bearing parcel can be larger
+			// than the last interest-free one.
+			continue
+		}
+
+		offers = append(offers, InstallmentOffer{
+			Installments:      n,
+			InterestFree:      interestFree,
+			InstallmentAmount: parcel,
+			TotalInterest:     plan.TotalInterest,
+			TotalIOF:          plan.TotalIOF,
+			TotalWithIOF:      plan.TotalWithIOF,
+			CET:               calc.CalculateCET(amount, plan),
+			Plan:              plan,
+		})
+	}
+	return offers
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestInstallmentService_Simulate(t *testing.T) {
+	svc := NewInstallmentService(testEngineConfig())
+	policy := config.InstallmentPolicyConfig{
+		MaxInstallments:      12,
+		InterestFreeUpTo:     3,
+		MonthlyRate:          19_900,
+		MinInstallmentAmount: 10_000,
+	}
+
+	offers := svc.Simulate(100_000, utcDate(2024, 1, 5), utcDate(2024, 2, 10), policy)
+
+	// R$ 1.000,00 with a R$ 100,00 minimum parcel: 1x..3x sem juros,
+	// 4x..11x com juros; 12x (PMT R$ 94,50) falls below the minimum.
+	if len(offers) != 11 {
+		t.Fatalf("expected 11 offers, got %d", len(offers))
+	}
+
+	for i, o := range offers {
+		if o.Installments != i+1 {
+			t.Fatalf("offer %d: expected %d installments, got %d", i, i+1, o.Installments)
+		}
+		if o.InterestFree != (o.Installments <= 3) {
+			t.Fatalf("offer %dx: unexpected interest-free flag %v", o.Installments, o.InterestFree)
+		}
+		if o.InterestFree && o.TotalInterest != 0 {
+			t.Fatalf("offer %dx: expected no interest, got %d", o.Installments, o.TotalInterest)
+		}
+		if !o.InterestFree && o.TotalInterest <= 0 {
+			t.Fatalf("offer %dx: expected interest", o.Installments)
+		}
+		if o.InstallmentAmount < policy.MinInstallmentAmount && o.Installments > 1 {
+			t.Fatalf("offer %dx: parcel %d below minimum", o.Installments, o.InstallmentAmount)
+		}
+		if o.TotalWithIOF != o.Plan.TotalWithIOF {
+			t.Fatalf("offer %dx: totals differ from plan", o.Installments)
+		}
+		if o.CET.Monthly <= 0 {
+			t.Fatalf("offer %dx: IOF should make the CET positive, got %d", o.Installments, o.CET.Monthly)
+		}
+	}
+
+	if offers[10].CET.Monthly <= offers[2].CET.Monthly {
+		t.Fatalf("expected interest-bearing offer to have a higher CET than interest-free")
+	}
+}
+
+func TestInstallmentService_Simulate_ReportsLargestParcel(t *testing.T) {
+	cfg := testEngineConfig()
+	cfg.Installment.Remainder = domain.RemainderLast
+	svc := NewInstallmentService(cfg)
+	policy := config.InstallmentPolicyConfig{MaxInstallments: 3, InterestFreeUpTo: 3}
+
+	offers := svc.Simulate(100_001, utcDate(2024, 1, 5), utcDate(2024, 2, 10), policy)
+
+	// 33333 + 33333 + 33335: the remainder goes to the last parcel.
+	if got := offers[2].InstallmentAmount; got != 33_335 {
+		t.Fatalf("expected the largest parcel 33335, got %d", got)
+	}
+}
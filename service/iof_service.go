@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+type IOFService struct {
+	IOFConfig              config.IOFConfig
+	InternationalIOFConfig config.InternationalIOFConfig
+}
+
+// Calculate computes the credit IOF (daily + additional, capped) for the principal.
+func (s *IOFService) Calculate(principal domain.Money, days int) domain.Money {
+	return calc.CalculateIOF(principal, days, s.IOFConfig)
+}
+
+// CalculateInternational computes the IOF of an international purchase.
+func (s *IOFService) CalculateInternational(amount domain.Money) domain.Money {
+	return calc.CalculateInternationalIOF(amount, s.InternationalIOFConfig)
+}
+
+func NewIOFService(cfg config.EngineConfig) *IOFService {
+	return &IOFService{
+		IOFConfig:              cfg.IOF,
+		InternationalIOFConfig: cfg.InternationalIOF,
+	}
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+)
+
+// resolveOverride looks up the active override for an account, tolerating a
+// nil provider and calls without an account ID.
+func resolveOverride(p config.OverrideProvider, accountID string, at time.Time) (config.RateOverride, bool) {
+	if p == nil || accountID == "" {
+		return config.RateOverride{}, false
+	}
+	return p.Resolve(accountID, at)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func testEngineConfig() config.EngineConfig {
+	return config.EngineConfig{
+		IOF:          config.IOFConfig{DailyRate: 82, AdditionalRate: 3_800, MaxAnnualRate: 40_800},
+		Interest:     config.InterestConfig{MonthlyRate: 120_000},
+		LateFee:      config.LateFeeConfig{Rate: 20_000},
+		LateInterest: config.LateInterestConfig{MonthlyRate: 10_000},
+		Rules:        config.RotativeRulesConfig{MaxDays: 30, MaxChargeRate: 1_000_000},
+		Installment:  config.InstallmentConfig{MonthlyRate: 19_900},
+		Payoff:       config.PayoffConfig{ValidityDays: 1},
+		Renegotiation: config.RenegotiationConfig{
+			InterestDiscount:   500_000,
+			LateFeeDiscount:    1_000_000,
+			MinDownPaymentRate: 100_000,
+			MonthlyRate:        49_900,
+			MaxInstallments:    24,
+		},
+	}
+}
+
+func utcDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRotativeService_AppliesOverride(t *testing.T) {
+	reduced := domain.Rate(60_000)
+	svc := NewRotativeService(testEngineConfig())
+	svc.Overrides = config.NewRateOverrides(config.RateOverride{
+		AccountID:           "acc-1",
+		ReasonCode:          "COBRANCA-REDUCAO",
+		EffectiveFrom:       utcDate(2024, 1, 1),
+		RotativeMonthlyRate: &reduced,
+	})
+
+	balance := domain.RotativeBalance{AccountID: "acc-1", Principal: 100_000, StartDate: utcDate(2024, 1, 1)}
+	result := svc.Calculate(balance, utcDate(2024, 1, 31))
+
+	if result.Interest != 6_000 {
+		t.Fatalf("expected negotiated interest 6000, got %d", result.Interest)
+	}
+	if result.Override == nil || result.Override.ReasonCode != "COBRANCA-REDUCAO" {
+		t.Fatalf("expected override reason code in result, got %+v", result.Override)
+	}
+
+	balance.AccountID = "acc-2"
+	result = svc.Calculate(balance, utcDate(2024, 1, 31))
+	if result.Interest != 12_000 || result.Override != nil {
+		t.Fatalf("expected product rates without override, got interest %d override %+v", result.Interest, result.Override)
+	}
+}
+
+func TestInstallmentService_AppliesOverrideInWindow(t *testing.T) {
+	free := domain.Rate(0)
+	svc := NewInstallmentService(testEngineConfig())
+	svc.Overrides = config.NewRateOverrides(config.RateOverride{
+		AccountID:              "acc-1",
+		ReasonCode:             "CAMPANHA",
+		EffectiveFrom:          utcDate(2024, 1, 1),
+		EffectiveUntil:         utcDate(2024, 2, 1),
+		InstallmentMonthlyRate: &free,
+	})
+
+	plan := svc.CalculateForAccount("acc-1", 100_000, 10, utcDate(2024, 1, 15), utcDate(2024, 2, 10))
+	if plan.TotalInterest != 0 || plan.Override == nil {
+		t.Fatalf("expected interest-free plan with override, got interest %d override %+v", plan.TotalInterest, plan.Override)
+	}
+
+	plan = svc.CalculateForAccount("acc-1", 100_000, 10, utcDate(2024, 2, 15), utcDate(2024, 3, 10))
+	if plan.TotalInterest == 0 || plan.Override != nil {
+		t.Fatalf("expected product rate after override expired, got interest %d override %+v", plan.TotalInterest, plan.Override)
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+// PayoffService quotes how much settles an account on a given date.
+type PayoffService struct {
+	IOFConfig          config.IOFConfig
+	InterestConfig     config.InterestConfig
+	LateFeeConfig      config.LateFeeConfig
+	LateInterestConfig config.LateInterestConfig
+	RulesConfig        config.RotativeRulesConfig
+	PayoffConfig       config.PayoffConfig
+	// Overrides holds per-account negotiated rates. Nil means product rates only.
+	Overrides config.OverrideProvider
+}
+
+// Quote returns the payoff of the account on at: billed amounts, rotative
+// charges accrued to at and the remaining installment parcels with the
+// early-settlement discount. Negotiated rotative rates active for accountID
+// at that date are applied.
+func (s *PayoffService) Quote(accountID string, in calc.PayoffInput, at time.Time) calc.PayoffQuote {
+	interest, lateFee, lateInterest := s.InterestConfig, s.LateFeeConfig, s.LateInterestConfig
+
+	var ref *domain.OverrideRef
+	if o, ok := resolveOverride(s.Overrides, accountID, at); ok {
+		interest, lateFee, lateInterest = o.ApplyRotative(interest, lateFee, lateInterest)
+		ref = o.Ref()
+	}
+
+	quote := calc.CalculatePayoff(in, at, s.IOFConfig, interest, lateFee, lateInterest, s.RulesConfig, s.PayoffConfig)
+	for i := range quote.Rotative {
+		quote.Rotative[i].Override = ref
+	}
+	return quote
+}
+
+func NewPayoffService(cfg config.EngineConfig) *PayoffService {
+	return &PayoffService{
+		IOFConfig:          cfg.IOF,
+		InterestConfig:     cfg.Interest,
+		LateFeeConfig:      cfg.LateFee,
+		LateInterestConfig: cfg.LateInterest,
+		RulesConfig:        cfg.Rules,
+		PayoffConfig:       cfg.Payoff,
+	}
+}
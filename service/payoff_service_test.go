@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestPayoffService_AppliesRotativeOverride(t *testing.T) {
+	reduced := domain.Rate(60_000)
+	svc := NewPayoffService(testEngineConfig())
+	svc.Overrides = config.NewRateOverrides(config.RateOverride{
+		AccountID:           "acc-1",
+		ReasonCode:          "COBRANCA-REDUCAO",
+		EffectiveFrom:       utcDate(2024, 1, 1),
+		RotativeMonthlyRate: &reduced,
+	})
+
+	in := calc.PayoffInput{
+		Billed:   2_000,
+		Rotative: []domain.RotativeBalance{{AccountID: "acc-1", Principal: 100_000, StartDate: utcDate(2024, 1, 1)}},
+	}
+	q := svc.Quote("acc-1", in, utcDate(2024, 1, 31))
+
+	if q.Rotative[0].Interest != 6_000 || q.Rotative[0].Override == nil {
+		t.Fatalf("expected negotiated interest 6000 with override, got %d (%v)", q.Rotative[0].Interest, q.Rotative[0].Override)
+	}
+	if q.Total != 2_000+q.Rotative[0].Total {
+		t.Fatalf("unexpected total %d", q.Total)
+	}
+	if !q.ValidUntil.After(q.Date) {
+		t.Fatalf("expected validity after quote date with default config")
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"time"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/config"
+)
+
+// RenegotiationService prices debt agreements (acordos) offered by collections.
+type RenegotiationService struct {
+	IOFConfig           config.IOFConfig
+	RenegotiationConfig config.RenegotiationConfig
+}
+
+// Propose prices an agreement for origin under the configured policy. See
+// calc.CalculateRenegotiation for the rules and the errors returned.
+func (s *RenegotiationService) Propose(origin calc.DebtOrigin, terms calc.RenegotiationTerms) (calc.Renegotiation, error) {
+	return calc.CalculateRenegotiation(origin, terms, s.IOFConfig, s.RenegotiationConfig)
+}
+
+// ProposeForRotative is like Propose for the debt of a rotative result
+// computed at, e.g. by RotativeService.Calculate. reference identifies the
+// original debt (e.g. the invoice ID) in the lineage of the agreement.
+func (s *RenegotiationService) ProposeForRotative(
+	accountID string,
+	reference string,
+	result calc.RotativeResult,
+	at time.Time,
+	terms calc.RenegotiationTerms,
+) (calc.Renegotiation, error) {
+	origin := calc.DebtOrigin{
+		AccountID: accountID,
+		Reference: reference,
+		Date:      at,
+		Debt:      calc.DebtFromRotative(result),
+	}
+	return s.Propose(origin, terms)
+}
+
+func NewRenegotiationService(cfg config.EngineConfig) *RenegotiationService {
+	return &RenegotiationService{
+		IOFConfig:           cfg.IOF,
+		RenegotiationConfig: cfg.Renegotiation,
+	}
+}
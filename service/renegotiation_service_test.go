@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagozs/go-calc-charges-engine/calc"
+	"github.com/thiagozs/go-calc-charges-engine/domain"
+)
+
+func TestRenegotiationService_ProposeForRotative(t *testing.T) {
+	cfg := testEngineConfig()
+	rotative := NewRotativeService(cfg).Calculate(
+		domain.RotativeBalance{AccountID: "acc-1", Principal: 100_000, StartDate: utcDate(2024, 1, 10)},
+		utcDate(2024, 2, 9),
+	)
+	svc := NewRenegotiationService(cfg)
+
+	terms := calc.RenegotiationTerms{Date: utcDate(2024, 3, 1), DownPayment: 20_000, Installments: 6, FirstDueDate: utcDate(2024, 4, 1)}
+	r, err := svc.ProposeForRotative("acc-1", "inv-2024-01", rotative, utcDate(2024, 2, 9), terms)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.Origin.AccountID != "acc-1" || r.Origin.Reference != "inv-2024-01" || r.Origin.Debt.Total() != rotative.Total {
+		t.Fatalf("expected lineage to the rotative debt, got %+v", r.Origin)
+	}
+	if r.Discount.Interest != rotative.Interest/2 || r.Discount.LateFee != rotative.LateFee {
+		t.Fatalf("expected half the interest and the whole late fee waived, got %+v", r.Discount)
+	}
+	if r.Plan.TotalAmount != r.Financed.Total() || len(r.Plan.Installments) != 6 {
+		t.Fatalf("expected a 6x plan of %d, got %+v", r.Financed.Total(), r.Plan)
+	}
+
+	terms.DownPayment = 1_000
+	if _, err := svc.ProposeForRotative("acc-1", "inv-2024-01", rotative, utcDate(2024, 2, 9), terms); !errors.Is(err, calc.ErrDownPaymentBelowMinimum) {
+		t.Fatalf("expected ErrDownPaymentBelowMinimum, got %v", err)
+	}
+}
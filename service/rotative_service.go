@@ -3,6 +3,7 @@ package service
 import (
 	"time"
 
+	"github.com/thiagozs/go-calc-charges-engine/audit"
 	"github.com/thiagozs/go-calc-charges-engine/calc"
 	"github.com/thiagozs/go-calc-charges-engine/config"
 	"github.com/thiagozs/go-calc-charges-engine/domain"
@@ -14,19 +15,77 @@ type RotativeService struct {
 	LateFeeConfig      config.LateFeeConfig
 	LateInterestConfig config.LateInterestConfig
 	RulesConfig        config.RotativeRulesConfig
+	// Overrides holds per-account negotiated rates. Nil means product rates only.
+	Overrides config.OverrideProvider
 }
 
+// Calculate computes the rotative charges for the balance at the given date.
+// If an override is active for balance.AccountID at that date, its rates are
+// merged over the product config and the override is recorded in the result.
 func (s *RotativeService) Calculate(balance domain.RotativeBalance,
 	at time.Time) calc.RotativeResult {
-	return calc.CalculateRotative(
+	result, _ := s.calculate(balance, at)
+	return result
+}
+
+// CalculateAudited is like Calculate, but also returns the audit envelope with
+// the input, the resolved config snapshot and the engine version.
+func (s *RotativeService) CalculateAudited(balance domain.RotativeBalance,
+	at time.Time) (calc.RotativeResult, audit.Envelope, error) {
+	result, cfg := s.calculate(balance, at)
+	env, err := audit.SealRotative(
+		audit.RotativeInput{Balance: balance, CalcDate: at, Override: result.Override},
+		cfg,
+		result,
+	)
+	if err != nil {
+		return calc.RotativeResult{}, audit.Envelope{}, err
+	}
+	return result, env, nil
+}
+
+// ApplyPayment applies a payment to the buckets of a rotative result,
+// following the banking order IOF -> juros -> mora -> multa -> principal.
+func (s *RotativeService) ApplyPayment(result calc.RotativeResult,
+	payment domain.Money) calc.AmortizationResult {
+	return calc.ApplyPayment(
+		result.Total,
+		result.IOF,
+		result.Interest,
+		result.LateInterest,
+		result.LateFee,
+		result.Principal,
+		payment,
+	)
+}
+
+func (s *RotativeService) calculate(balance domain.RotativeBalance,
+	at time.Time) (calc.RotativeResult, audit.RotativeConfig) {
+	cfg := audit.RotativeConfig{
+		IOF:          s.IOFConfig,
+		Interest:     s.InterestConfig,
+		LateFee:      s.LateFeeConfig,
+		LateInterest: s.LateInterestConfig,
+		Rules:        s.RulesConfig,
+	}
+
+	var ref *domain.OverrideRef
+	if o, ok := resolveOverride(s.Overrides, balance.AccountID, at); ok {
+		cfg.Interest, cfg.LateFee, cfg.LateInterest = o.ApplyRotative(cfg.Interest, cfg.LateFee, cfg.LateInterest)
+		ref = o.Ref()
+	}
+
+	result := calc.CalculateRotative(
 		balance,
 		at,
-		s.IOFConfig,
-		s.InterestConfig,
-		s.LateFeeConfig,
-		s.LateInterestConfig,
-		s.RulesConfig,
+		cfg.IOF,
+		cfg.Interest,
+		cfg.LateFee,
+		cfg.LateInterest,
+		cfg.Rules,
 	)
+	result.Override = ref
+	return result, cfg
 }
 
 func NewRotativeService(cfg config.EngineConfig) *RotativeService {